@@ -0,0 +1,227 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ClusterDBaaSPolicyName is the name of the ClusterDBaaSPolicy, which is a singleton
+const ClusterDBaaSPolicyName = "cluster"
+
+// ClusterDBaaSPolicySpec sets the defaults and upper bounds of the inventory policy of every inventory namespace
+type ClusterDBaaSPolicySpec struct {
+	// Defaults of the inventory policy, used for the fields that neither the DBaaSPolicies of an inventory's namespace nor the inventory set
+	Defaults *DBaaSInventoryPolicy `json:"defaults,omitempty"`
+
+	// Upper bounds of the inventory policy. DBaaSPolicies and inventories may tighten them but not loosen them.
+	// If disableProvisions is true, provisioning is disabled against all the inventories, and if requireInstanceApproval
+	// is true, provisioning requires an approval against all the inventories. If connectionNamespaces or
+	// connectionNsSelector are set, DBaaSConnections/DBaaSInstances outside of the inventory's namespace are only
+	// allowed in the namespaces they select, "*" selecting all namespaces. The maximums cap the maximums of
+	// DBaaSPolicies and inventories, and apply where they are not set. Only the providers allowed by both the
+	// bounds and the DBaaSPolicies are allowed, and the providers denied by either are denied. The same goes for the
+	// allowed cloud providers and regions, and instances must satisfy the parameter constraints of both.
+	Bounds *DBaaSInventoryPolicy `json:"bounds,omitempty"`
+}
+
+// ClusterDBaaSPolicyStatus defines the observed state of ClusterDBaaSPolicy
+type ClusterDBaaSPolicyStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterDBaaSPolicy is the Schema for the clusterdbaaspolicies API. The cluster policy, named "cluster",
+// sets the defaults and upper bounds of the inventory policy of every inventory namespace.
+//+operator-sdk:csv:customresourcedefinitions:displayName="Cluster Provider Account Policy"
+type ClusterDBaaSPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDBaaSPolicySpec   `json:"spec,omitempty"`
+	Status ClusterDBaaSPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterDBaaSPolicyList contains a list of ClusterDBaaSPolicy
+type ClusterDBaaSPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDBaaSPolicy `json:"items"`
+}
+
+// Apply returns the policy with the cluster defaults set for its unset fields, and bounded by the cluster bounds.
+// The connection namespaces are not bounded, see AllowsNamespace. A nil cluster policy returns the policy unchanged.
+func (p *ClusterDBaaSPolicy) Apply(policy *DBaaSInventoryPolicy) *DBaaSInventoryPolicy {
+	effective := &DBaaSInventoryPolicy{}
+	if policy != nil {
+		effective = policy.DeepCopy()
+	}
+	if p == nil {
+		return effective
+	}
+	if defaults := p.Spec.Defaults; defaults != nil {
+		effective = effective.Override(defaults)
+	}
+	if bounds := p.Spec.Bounds; bounds != nil {
+		if bounds.DisableProvisions != nil && *bounds.DisableProvisions {
+			disable := true
+			effective.DisableProvisions = &disable
+		}
+		if bounds.RequireInstanceApproval != nil && *bounds.RequireInstanceApproval {
+			require := true
+			effective.RequireInstanceApproval = &require
+		}
+		effective.MaxInstancesPerNamespace = minInt32(effective.MaxInstancesPerNamespace, bounds.MaxInstancesPerNamespace)
+		effective.MaxConnectionsPerNamespace = minInt32(effective.MaxConnectionsPerNamespace, bounds.MaxConnectionsPerNamespace)
+		if bounds.AllowedProviders != nil {
+			effective.AllowedProviders = intersectStrings(effective.AllowedProviders, *bounds.AllowedProviders)
+		}
+		effective.DeniedProviders = unionStrings(effective.DeniedProviders, bounds.DeniedProviders)
+		if bounds.AllowedCloudProviders != nil {
+			effective.AllowedCloudProviders = intersectStrings(effective.AllowedCloudProviders, *bounds.AllowedCloudProviders)
+		}
+		if bounds.AllowedCloudRegions != nil {
+			effective.AllowedCloudRegions = intersectStrings(effective.AllowedCloudRegions, *bounds.AllowedCloudRegions)
+		}
+		// instances must satisfy both the constraints of the policy and the bounds
+		effective.InstanceParamConstraints = append(effective.InstanceParamConstraints, bounds.InstanceParamConstraints...)
+		if bounds.MaxInstanceTTL != nil && (effective.MaxInstanceTTL == nil || effective.MaxInstanceTTL.Duration > bounds.MaxInstanceTTL.Duration) {
+			effective.MaxInstanceTTL = bounds.MaxInstanceTTL.DeepCopy()
+		}
+	}
+	return effective
+}
+
+// AllowsNamespace checks whether the cluster bounds allow DBaaSConnections/DBaaSInstances in a namespace to reference
+// the inventories of another namespace. A nil cluster policy allows all namespaces.
+func (p *ClusterDBaaSPolicy) AllowsNamespace(namespace string, namespaceLabels map[string]string) (bool, error) {
+	if p == nil || p.Spec.Bounds == nil {
+		return true, nil
+	}
+	bounds := p.Spec.Bounds
+	if bounds.ConnectionNamespaces != nil {
+		allowed := false
+		for _, ns := range *bounds.ConnectionNamespaces {
+			if ns == "*" || ns == namespace {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+	if bounds.ConnectionNsSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(bounds.ConnectionNsSelector)
+		if err != nil {
+			return false, err
+		}
+		return selector.Matches(labels.Set(namespaceLabels)), nil
+	}
+	return true, nil
+}
+
+// Override returns the policy with the fields it does not set taken from a base policy
+func (p *DBaaSInventoryPolicy) Override(base *DBaaSInventoryPolicy) *DBaaSInventoryPolicy {
+	effective := p.DeepCopy()
+	if base == nil {
+		return effective
+	}
+	base = base.DeepCopy()
+	if effective.DisableProvisions == nil {
+		effective.DisableProvisions = base.DisableProvisions
+	}
+	if effective.ConnectionNamespaces == nil {
+		effective.ConnectionNamespaces = base.ConnectionNamespaces
+	}
+	if effective.ConnectionNsSelector == nil {
+		effective.ConnectionNsSelector = base.ConnectionNsSelector
+	}
+	if effective.TeardownOnInvalidNamespace == nil {
+		effective.TeardownOnInvalidNamespace = base.TeardownOnInvalidNamespace
+	}
+	if effective.MaxInstancesPerNamespace == nil {
+		effective.MaxInstancesPerNamespace = base.MaxInstancesPerNamespace
+	}
+	if effective.MaxConnectionsPerNamespace == nil {
+		effective.MaxConnectionsPerNamespace = base.MaxConnectionsPerNamespace
+	}
+	if effective.MaxInstanceTTL == nil {
+		effective.MaxInstanceTTL = base.MaxInstanceTTL
+	}
+	if effective.AllowedProviders == nil {
+		effective.AllowedProviders = base.AllowedProviders
+	}
+	if effective.DeniedProviders == nil {
+		effective.DeniedProviders = base.DeniedProviders
+	}
+	if effective.AllowedCloudProviders == nil {
+		effective.AllowedCloudProviders = base.AllowedCloudProviders
+	}
+	if effective.AllowedCloudRegions == nil {
+		effective.AllowedCloudRegions = base.AllowedCloudRegions
+	}
+	if effective.RequireInstanceApproval == nil {
+		effective.RequireInstanceApproval = base.RequireInstanceApproval
+	}
+	effective.InstanceParamConstraints = overrideParamConstraints(effective.InstanceParamConstraints, base.InstanceParamConstraints)
+	return effective
+}
+
+// InventoryPolicy returns the policy of an inventory. The inventory settings take precedence over the effective
+// policy of its namespace, and the cluster policy sets the defaults and upper bounds of both.
+func InventoryPolicy(inventory *DBaaSInventory, namespacePolicy *DBaaSInventoryPolicy, clusterPolicy *ClusterDBaaSPolicy) *DBaaSInventoryPolicy {
+	return clusterPolicy.Apply(inventory.Spec.DBaaSInventoryPolicy.Override(namespacePolicy))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectStrings returns the bound values that are also in a list, a nil list holding all the values
+func intersectStrings(values *[]string, bound []string) *[]string {
+	intersection := []string{}
+	for _, value := range bound {
+		if values == nil || containsString(*values, value) {
+			intersection = append(intersection, value)
+		}
+	}
+	return &intersection
+}
+
+func minInt32(value, bound *int32) *int32 {
+	if bound == nil || (value != nil && *value <= *bound) {
+		return value
+	}
+	min := *bound
+	return &min
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDBaaSPolicy{}, &ClusterDBaaSPolicyList{})
+}
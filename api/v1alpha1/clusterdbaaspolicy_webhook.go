@@ -0,0 +1,150 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var clusterdbaaspolicylog = logf.Log.WithName("clusterdbaaspolicy-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *ClusterDBaaSPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-clusterdbaaspolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=clusterdbaaspolicies,verbs=create;update,versions=v1alpha1,name=vclusterdbaaspolicy.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ClusterDBaaSPolicy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ClusterDBaaSPolicy) ValidateCreate() error {
+	clusterdbaaspolicylog.Info("validate create", "name", r.Name)
+	return validateClusterPolicy(r)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ClusterDBaaSPolicy) ValidateUpdate(_ runtime.Object) error {
+	clusterdbaaspolicylog.Info("validate update", "name", r.Name)
+	return validateClusterPolicy(r)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ClusterDBaaSPolicy) ValidateDelete() error {
+	clusterdbaaspolicylog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func validateClusterPolicy(clusterPolicy *ClusterDBaaSPolicy) error {
+	if clusterPolicy.Name != ClusterDBaaSPolicyName {
+		return field.Invalid(field.NewPath("metadata").Child("name"), clusterPolicy.Name, fmt.Sprintf("the cluster policy must be named %s", ClusterDBaaSPolicyName))
+	}
+	for _, policy := range []struct {
+		path   string
+		policy *DBaaSInventoryPolicy
+	}{{"defaults", clusterPolicy.Spec.Defaults}, {"bounds", clusterPolicy.Spec.Bounds}} {
+		if policy.policy == nil {
+			continue
+		}
+		path := field.NewPath("spec").Child(policy.path)
+		if err := validateParamConstraints(policy.policy.InstanceParamConstraints, path.Child("instanceParamConstraints")); err != nil {
+			return err
+		}
+		if policy.policy.ConnectionNsSelector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(policy.policy.ConnectionNsSelector); err != nil {
+			return field.Invalid(path.Child("connectionNsSelector"), policy.policy.ConnectionNsSelector, err.Error())
+		}
+	}
+	if defaults, bounds := clusterPolicy.Spec.Defaults, clusterPolicy.Spec.Bounds; defaults != nil {
+		return validatePolicyBounds(defaults, bounds, field.NewPath("spec").Child("defaults"))
+	}
+	return nil
+}
+
+// validatePolicyBounds checks that a policy does not loosen the bounds of the cluster policy
+func validatePolicyBounds(policy *DBaaSInventoryPolicy, bounds *DBaaSInventoryPolicy, path *field.Path) error {
+	if bounds == nil {
+		return nil
+	}
+	if bounds.DisableProvisions != nil && *bounds.DisableProvisions && policy.DisableProvisions != nil && !*policy.DisableProvisions {
+		return field.Invalid(path.Child("disableProvisions"), *policy.DisableProvisions, "provisioning is disabled by the cluster policy")
+	}
+	if bounds.RequireInstanceApproval != nil && *bounds.RequireInstanceApproval && policy.RequireInstanceApproval != nil && !*policy.RequireInstanceApproval {
+		return field.Invalid(path.Child("requireInstanceApproval"), *policy.RequireInstanceApproval, "approval is required by the cluster policy")
+	}
+	if bounds.ConnectionNamespaces != nil && policy.ConnectionNamespaces != nil {
+		allowed := map[string]bool{}
+		for _, ns := range *bounds.ConnectionNamespaces {
+			allowed[ns] = true
+		}
+		if !allowed["*"] {
+			for _, ns := range *policy.ConnectionNamespaces {
+				if !allowed[ns] {
+					return field.Invalid(path.Child("connectionNamespaces"), ns, "namespace is not allowed by the cluster policy")
+				}
+			}
+		}
+	}
+	for _, allowed := range []struct {
+		path          string
+		kind          string
+		values, bound *[]string
+	}{
+		{"allowedProviders", "provider", policy.AllowedProviders, bounds.AllowedProviders},
+		{"allowedCloudProviders", "cloud provider", policy.AllowedCloudProviders, bounds.AllowedCloudProviders},
+		{"allowedCloudRegions", "cloud region", policy.AllowedCloudRegions, bounds.AllowedCloudRegions},
+	} {
+		if allowed.bound == nil || allowed.values == nil {
+			continue
+		}
+		for _, value := range *allowed.values {
+			if !containsString(*allowed.bound, value) {
+				return field.Invalid(path.Child(allowed.path), value, fmt.Sprintf("%s is not allowed by the cluster policy", allowed.kind))
+			}
+		}
+	}
+	if err := validateMaxBound(policy.MaxInstancesPerNamespace, bounds.MaxInstancesPerNamespace, path.Child("maxInstancesPerNamespace")); err != nil {
+		return err
+	}
+	if err := validateMaxBound(policy.MaxConnectionsPerNamespace, bounds.MaxConnectionsPerNamespace, path.Child("maxConnectionsPerNamespace")); err != nil {
+		return err
+	}
+	if policy.MaxInstanceTTL != nil && bounds.MaxInstanceTTL != nil && policy.MaxInstanceTTL.Duration > bounds.MaxInstanceTTL.Duration {
+		return field.Invalid(path.Child("maxInstanceTTL"), policy.MaxInstanceTTL.Duration.String(),
+			fmt.Sprintf("exceeds the maximum of %s set by the cluster policy", bounds.MaxInstanceTTL.Duration))
+	}
+	return nil
+}
+
+func validateMaxBound(value, bound *int32, path *field.Path) error {
+	if value != nil && bound != nil && *value > *bound {
+		return field.Invalid(path, *value, fmt.Sprintf("exceeds the maximum of %d set by the cluster policy", *bound))
+	}
+	return nil
+}
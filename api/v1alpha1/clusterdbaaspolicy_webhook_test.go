@@ -0,0 +1,87 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ClusterDBaaSPolicy Webhook", func() {
+	maxInstances := int32(5)
+	clusterPolicy := &ClusterDBaaSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ClusterDBaaSPolicyName,
+		},
+		Spec: ClusterDBaaSPolicySpec{
+			Bounds: &DBaaSInventoryPolicy{
+				ConnectionNamespaces:     &[]string{"test-namespace-app"},
+				MaxInstancesPerNamespace: &maxInstances,
+			},
+		},
+	}
+
+	Context("creation fails", func() {
+		It("should reject another name", func() {
+			policy := clusterPolicy.DeepCopy()
+			policy.Name = "testclusterpolicy"
+			err := k8sClient.Create(ctx, policy)
+			Expect(err).Should(MatchError("admission webhook \"vclusterdbaaspolicy.kb.io\" denied the request: metadata.name: Invalid value: \"testclusterpolicy\": the cluster policy must be named cluster"))
+		})
+
+		It("should reject defaults loosening the bounds", func() {
+			policy := clusterPolicy.DeepCopy()
+			policy.Spec.Defaults = &DBaaSInventoryPolicy{
+				ConnectionNamespaces: &[]string{"*"},
+			}
+			err := k8sClient.Create(ctx, policy)
+			Expect(err).Should(MatchError("admission webhook \"vclusterdbaaspolicy.kb.io\" denied the request: spec.defaults.connectionNamespaces: Invalid value: \"*\": namespace is not allowed by the cluster policy"))
+		})
+	})
+
+	Context("after creating the cluster policy", func() {
+		BeforeEach(func() {
+			policy := clusterPolicy.DeepCopy()
+			Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+		})
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, clusterPolicy.DeepCopy())).Should(Succeed())
+		})
+
+		It("should reject namespace policies loosening the bounds", func() {
+			policy := testDBaaSPolicy.DeepCopy()
+			policy.Name = "testpolicy-bounds"
+			policy.SetResourceVersion("")
+			limit := int32(10)
+			policy.Spec.MaxInstancesPerNamespace = &limit
+			err := k8sClient.Create(ctx, policy)
+			Expect(err).Should(MatchError("admission webhook \"vdbaaspolicy.kb.io\" denied the request: spec.maxInstancesPerNamespace: Invalid value: 10: exceeds the maximum of 5 set by the cluster policy"))
+		})
+
+		It("should allow namespace policies tightening the bounds", func() {
+			policy := testDBaaSPolicy.DeepCopy()
+			policy.Name = "testpolicy-bounds"
+			policy.SetResourceVersion("")
+			limit := int32(2)
+			policy.Spec.MaxInstancesPerNamespace = &limit
+			policy.Spec.ConnectionNamespaces = &[]string{"test-namespace-app"}
+			Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, policy)).Should(Succeed())
+		})
+	})
+})
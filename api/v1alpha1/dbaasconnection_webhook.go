@@ -17,20 +17,30 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 // log is for logging in this package.
 var dbaasconnectionlog = logf.Log.WithName("dbaasconnection-resource")
+var connectionWebhookAPIClient client.Client
 
 // SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSConnection) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if connectionWebhookAPIClient == nil {
+		connectionWebhookAPIClient = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -43,7 +53,13 @@ var _ webhook.Validator = &DBaaSConnection{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSConnection) ValidateCreate() error {
 	dbaasconnectionlog.Info("validate create", "name", r.Name)
-	return r.validateCreateDBaaSConnectionSpec()
+	if err := r.validateCreateDBaaSConnectionSpec(); err != nil {
+		return err
+	}
+	if err := r.validateInventoryInstance(); err != nil {
+		return err
+	}
+	return r.validateConnectionQuota()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -74,7 +90,19 @@ func (r *DBaaSConnection) validateUpdateDBaaSConnectionSpec(old *DBaaSConnection
 	}
 
 	if !reflect.DeepEqual(r.Spec.InventoryRef, old.Spec.InventoryRef) {
-		return field.Invalid(field.NewPath("spec").Child("inventoryRef"), r.Spec.InventoryRef, "inventoryRef is immutable")
+		migration, err := r.isInventoryMigration(old)
+		if err != nil {
+			return err
+		}
+		if !migration {
+			return field.Invalid(field.NewPath("spec").Child("inventoryRef"), r.Spec.InventoryRef, "inventoryRef is immutable")
+		}
+		// the connection moves to another inventory, it counts against the quota of the new one
+		if r.inventoryKey() != old.inventoryKey() {
+			if err := r.validateConnectionQuota(); err != nil {
+				return err
+			}
+		}
 	}
 
 	if !reflect.DeepEqual(r.Spec.InstanceRef, old.Spec.InstanceRef) {
@@ -83,3 +111,92 @@ func (r *DBaaSConnection) validateUpdateDBaaSConnectionSpec(old *DBaaSConnection
 
 	return nil
 }
+
+// validateInventoryInstance checks that the inventory is ready, that it exposes the instance ID,
+// and that the connection namespace is allowed to use its instances
+func (r *DBaaSConnection) validateInventoryInstance() error {
+	if len(r.Spec.InstanceID) == 0 {
+		return nil
+	}
+	inventory := &DBaaSInventory{}
+	if err := connectionWebhookAPIClient.Get(context.TODO(), r.inventoryKey(), inventory); err != nil {
+		if errors.IsNotFound(err) {
+			// the controller reports the missing inventory
+			return nil
+		}
+		return err
+	}
+	inventoryPath := field.NewPath("spec").Child("inventoryRef")
+	if !apimeta.IsStatusConditionTrue(inventory.Status.Conditions, DBaaSInventoryReadyType) {
+		return field.Invalid(inventoryPath, r.Spec.InventoryRef, fmt.Sprintf("inventory %s is not ready", inventory.Name))
+	}
+	found, err := hasInventoryInstance(connectionWebhookAPIClient, inventory, r.Spec.InstanceID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return field.Invalid(field.NewPath("spec").Child("instanceID"), r.Spec.InstanceID,
+			fmt.Sprintf("instance not found or hidden in inventory %s", inventory.Name))
+	}
+	validNS, err := IsValidConnectionNamespace(context.TODO(), connectionWebhookAPIClient, r.Namespace, inventory)
+	if err != nil {
+		return err
+	}
+	if !validNS {
+		return field.Invalid(inventoryPath, r.Spec.InventoryRef, fmt.Sprintf("namespace %s is not allowed to connect to instances of inventory %s", r.Namespace, inventory.Name))
+	}
+	return nil
+}
+
+// isInventoryMigration checks whether the inventory reference of the connection is changed by the
+// DBaaSInventoryMigration named in its migration annotation
+func (r *DBaaSConnection) isInventoryMigration(old *DBaaSConnection) (bool, error) {
+	name, ok := r.GetAnnotations()[MigrationAnnotation]
+	if !ok {
+		return false, nil
+	}
+	source, target := old.inventoryKey(), r.inventoryKey()
+	migration := &DBaaSInventoryMigration{}
+	if err := connectionWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: source.Namespace}, migration); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return migration.Status.Phase != MigrationPhaseCompleted && migration.Status.Phase != MigrationPhaseFailed &&
+		migration.Spec.SourceInventoryRef.Name == source.Name &&
+		migration.Spec.TargetInventoryRef.Name == target.Name && target.Namespace == source.Namespace, nil
+}
+
+// inventoryKey returns the key of the inventory referenced by the connection, defaulting to the connection namespace
+func (r *DBaaSConnection) inventoryKey() types.NamespacedName {
+	key := types.NamespacedName{Name: r.Spec.InventoryRef.Name, Namespace: r.Spec.InventoryRef.Namespace}
+	if len(key.Namespace) == 0 {
+		key.Namespace = r.Namespace
+	}
+	return key
+}
+
+func (r *DBaaSConnection) validateConnectionQuota() error {
+	key := r.inventoryKey()
+	inventoryRef := NamespacedName{Name: key.Name, Namespace: key.Namespace}
+	limit, err := getNamespaceLimit(connectionWebhookAPIClient, inventoryRef, func(policy *DBaaSInventoryPolicy) *int32 {
+		return policy.MaxConnectionsPerNamespace
+	})
+	if err != nil || limit == nil {
+		return err
+	}
+	// the limit applies to the connections of the namespace referencing the inventory
+	connectionList := &DBaaSConnectionList{}
+	if err := connectionWebhookAPIClient.List(context.TODO(), connectionList, client.InNamespace(r.Namespace),
+		client.MatchingFields{InventoryRefKey: InventoryRefIndexValue(inventoryRef)}); err != nil {
+		return err
+	}
+	used := 0
+	for i := range connectionList.Items {
+		if connectionList.Items[i].Name != r.Name {
+			used++
+		}
+	}
+	return validateNamespaceQuota(r.Namespace, "connections", used, limit, inventoryRef)
+}
@@ -109,6 +109,48 @@ var _ = Describe("DBaaSConnection Webhook", func() {
 					"spec.instanceRef: Invalid value: v1alpha1.NamespacedName{Namespace:\"default\", Name:\"updated-instance\"}: "+
 					"instanceRef is immutable"),
 		)
+
+		Context("with an inventory migration", func() {
+			migration := &DBaaSInventoryMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-connection-migration",
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSInventoryMigrationSpec{
+					SourceInventoryRef: LocalObjectReference{Name: inventoryName},
+					TargetInventoryRef: LocalObjectReference{Name: "test-inventory-target"},
+				},
+			}
+			BeforeEach(assertResourceCreation(migration))
+			AfterEach(assertResourceDeletion(migration))
+
+			It("should not allow updating inventoryRef without the migration annotation", func() {
+				updatedDBaaSConnection := &DBaaSConnection{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSConnection), updatedDBaaSConnection)).Should(Succeed())
+				updatedDBaaSConnection.Spec.InventoryRef.Name = migration.Spec.TargetInventoryRef.Name
+				Expect(k8sClient.Update(ctx, updatedDBaaSConnection)).Should(MatchError("admission webhook \"vdbaasconnection.kb.io\" denied the request: " +
+					"spec.inventoryRef: Invalid value: v1alpha1.NamespacedName{Namespace:\"default\", Name:\"test-inventory-target\"}: " +
+					"inventoryRef is immutable"))
+			})
+
+			It("should not allow updating inventoryRef to another inventory than the migration target", func() {
+				updatedDBaaSConnection := &DBaaSConnection{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSConnection), updatedDBaaSConnection)).Should(Succeed())
+				updatedDBaaSConnection.SetAnnotations(map[string]string{MigrationAnnotation: migration.Name})
+				updatedDBaaSConnection.Spec.InventoryRef.Name = "updated-inventory"
+				Expect(k8sClient.Update(ctx, updatedDBaaSConnection)).Should(MatchError("admission webhook \"vdbaasconnection.kb.io\" denied the request: " +
+					"spec.inventoryRef: Invalid value: v1alpha1.NamespacedName{Namespace:\"default\", Name:\"updated-inventory\"}: " +
+					"inventoryRef is immutable"))
+			})
+
+			It("should allow the migration to update inventoryRef", func() {
+				updatedDBaaSConnection := &DBaaSConnection{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSConnection), updatedDBaaSConnection)).Should(Succeed())
+				updatedDBaaSConnection.SetAnnotations(map[string]string{MigrationAnnotation: migration.Name})
+				updatedDBaaSConnection.Spec.InventoryRef.Name = migration.Spec.TargetInventoryRef.Name
+				Expect(k8sClient.Update(ctx, updatedDBaaSConnection)).Should(Succeed())
+			})
+		})
 	})
 
 	Context("after trying to create DBaaSConnection without instance info", func() {
@@ -269,4 +311,40 @@ var _ = Describe("DBaaSConnection Webhook", func() {
 				"instanceRef is immutable"))
 		})
 	})
+
+	Context("after creating the referenced DBaaSInventory", func() {
+		inventory := testDBaaSInventory.DeepCopy()
+		inventory.Name = "test-inventory-admission"
+		connection := testDBaaSConnection.DeepCopy()
+		connection.Name = "test-connection-admission"
+		connection.Spec.InventoryRef.Name = inventory.Name
+		BeforeEach(assertResourceCreation(&testSecret))
+		BeforeEach(assertResourceCreation(&testProvider))
+		BeforeEach(assertResourceCreation(inventory))
+		AfterEach(assertResourceDeletion(inventory))
+		AfterEach(assertResourceDeletion(&testProvider))
+		AfterEach(assertResourceDeletion(&testSecret))
+
+		It("should not allow connecting before the inventory is ready", func() {
+			connection.SetResourceVersion("")
+			err := k8sClient.Create(ctx, connection)
+			Expect(err).Should(MatchError("admission webhook \"vdbaasconnection.kb.io\" denied the request: " +
+				"spec.inventoryRef: Invalid value: v1alpha1.NamespacedName{Namespace:\"default\", Name:\"test-inventory-admission\"}: " +
+				"inventory test-inventory-admission is not ready"))
+		})
+
+		It("should not allow connecting to an unknown instance", func() {
+			setInventoryReady(inventory, "other-instanceID")
+			connection.SetResourceVersion("")
+			err := k8sClient.Create(ctx, connection)
+			Expect(err).Should(MatchError("admission webhook \"vdbaasconnection.kb.io\" denied the request: " +
+				"spec.instanceID: Invalid value: \"test-instanceID\": instance not found or hidden in inventory test-inventory-admission"))
+		})
+
+		It("should allow connecting to a discovered instance", func() {
+			setInventoryReady(inventory, instanceID)
+			assertResourceCreation(connection)()
+			assertResourceDeletion(connection)()
+		})
+	})
 })
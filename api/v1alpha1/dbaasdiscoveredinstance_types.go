@@ -0,0 +1,81 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DBaaSDiscoveredInstanceSpec defines an instance discovered by an inventory.
+// It is set by the operator from the inventory status, any change is reverted.
+type DBaaSDiscoveredInstanceSpec struct {
+	// A reference to the DBaaSInventory that discovered the instance
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// A reference to the DBaaSProvider of the inventory
+	ProviderRef NamespacedName `json:"providerRef"`
+
+	// The discovered instance
+	Instance `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Instance ID",type=string,JSONPath=`.spec.instanceID`
+//+kubebuilder:printcolumn:name="Instance Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Inventory",type=string,JSONPath=`.spec.inventoryRef.name`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DBaaSDiscoveredInstance is the Schema for the dbaasdiscoveredinstances API.
+// The operator creates one read-only DBaaSDiscoveredInstance per instance exposed by an inventory,
+// in the namespace of the inventory, labelled with the names of the provider and the inventory.
+//+operator-sdk:csv:customresourcedefinitions:displayName="DBaaSDiscoveredInstance"
+type DBaaSDiscoveredInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DBaaSDiscoveredInstanceSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSDiscoveredInstanceList contains a list of DBaaSDiscoveredInstance
+type DBaaSDiscoveredInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSDiscoveredInstance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSDiscoveredInstance{}, &DBaaSDiscoveredInstanceList{})
+}
+
+// DiscoveredInstanceName returns the name of the DBaaSDiscoveredInstance of an instance discovered by an inventory.
+// Instance IDs are provider-specific and may not be valid object names, so the name uses a hash of the ID.
+func DiscoveredInstanceName(inventoryName, instanceID string) string {
+	h := fnv.New64a()
+	h.Write([]byte(instanceID))
+	suffix := fmt.Sprintf("-%016x", h.Sum64())
+	if maxLen := validation.DNS1123SubdomainMaxLength - len(suffix); len(inventoryName) > maxLen {
+		inventoryName = strings.TrimRight(inventoryName[:maxLen], ".")
+	}
+	return inventoryName + suffix
+}
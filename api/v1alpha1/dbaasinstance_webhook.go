@@ -0,0 +1,345 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasinstancelog = logf.Log.WithName("dbaasinstance-resource")
+var instanceWebhookAPIClient client.Client
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSInstance) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if instanceWebhookAPIClient == nil {
+		instanceWebhookAPIClient = mgr.GetClient()
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasinstance,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstances,verbs=create;update,versions=v1alpha1,name=vdbaasinstance.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &DBaaSInstance{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateCreate() error {
+	dbaasinstancelog.Info("validate create", "name", r.Name)
+	if err := r.validateInventoryRef(); err != nil {
+		return err
+	}
+	if err := r.validateExpiration(); err != nil {
+		return err
+	}
+	if err := r.validatePowerState(); err != nil {
+		return err
+	}
+	if err := r.validateCloneSource(); err != nil {
+		return err
+	}
+	if err := r.validateInstancePolicy(); err != nil {
+		return err
+	}
+	return r.validateInstanceQuota()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateUpdate(old runtime.Object) error {
+	dbaasinstancelog.Info("validate update", "name", r.Name)
+	if err := r.validateExpiration(); err != nil {
+		return err
+	}
+	if err := r.validatePowerState(); err != nil {
+		return err
+	}
+	oldInstance, ok := old.(*DBaaSInstance)
+	if !ok {
+		return fmt.Errorf("runtime object is not of type DBaaSInstance")
+	}
+	// the clone source is only used when provisioning the instance
+	if !reflect.DeepEqual(r.Spec.CloneSource, oldInstance.Spec.CloneSource) {
+		return field.Invalid(field.NewPath("spec").Child("cloneSource"), r.Spec.CloneSource, "cloneSource is immutable")
+	}
+	if r.Spec.InventoryRef != oldInstance.Spec.InventoryRef || r.Spec.InstanceClassName != oldInstance.Spec.InstanceClassName {
+		if err := r.validateInventoryRef(); err != nil {
+			return err
+		}
+		// the instance may move to another inventory, it then counts against the quota of the new one
+		inventoryRef, err := getInstanceInventoryRef(r)
+		if err != nil {
+			return err
+		}
+		oldInventoryRef, err := getInstanceInventoryRef(oldInstance)
+		if err != nil {
+			return err
+		}
+		if inventoryRef != nil && (oldInventoryRef == nil || *inventoryRef != *oldInventoryRef) {
+			if err := r.validateInstanceQuota(); err != nil {
+				return err
+			}
+		}
+	}
+	// instances provisioned before a policy change are only checked when their provisioning parameters change
+	if r.Spec.InventoryRef == oldInstance.Spec.InventoryRef && r.Spec.InstanceClassName == oldInstance.Spec.InstanceClassName &&
+		r.Spec.CloudProvider == oldInstance.Spec.CloudProvider && r.Spec.CloudRegion == oldInstance.Spec.CloudRegion &&
+		reflect.DeepEqual(r.Spec.OtherInstanceParams, oldInstance.Spec.OtherInstanceParams) {
+		return nil
+	}
+	return r.validateInstancePolicy()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateDelete() error {
+	dbaasinstancelog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *DBaaSInstance) validateInstanceQuota() error {
+	inventoryRef, err := getInstanceInventoryRef(r)
+	if err != nil || inventoryRef == nil {
+		return err
+	}
+	limit, err := getNamespaceLimit(instanceWebhookAPIClient, *inventoryRef, func(policy *DBaaSInventoryPolicy) *int32 {
+		return policy.MaxInstancesPerNamespace
+	})
+	if err != nil || limit == nil {
+		return err
+	}
+	// the limit applies to the instances of the namespace resolving to the inventory
+	instanceList := &DBaaSInstanceList{}
+	if err := instanceWebhookAPIClient.List(context.TODO(), instanceList, client.InNamespace(r.Namespace),
+		client.MatchingFields{InventoryRefKey: InventoryRefIndexValue(*inventoryRef)}); err != nil {
+		return err
+	}
+	used := 0
+	for i := range instanceList.Items {
+		if instanceList.Items[i].Name != r.Name {
+			used++
+		}
+	}
+	return validateNamespaceQuota(r.Namespace, "instances", used, limit, *inventoryRef)
+}
+
+// get the inventory reference of an instance, see ResolveInventoryRef. returns nil if the instance class does not exist.
+func getInstanceInventoryRef(instance *DBaaSInstance) (*NamespacedName, error) {
+	return ResolveInventoryRef(context.TODO(), instanceWebhookAPIClient, instance)
+}
+
+// validateInstancePolicy checks that the policy of the inventory allows the cloud provider, region and parameters of the instance
+func (r *DBaaSInstance) validateInstancePolicy() error {
+	spec := &r.Spec
+	if len(r.Spec.InstanceClassName) > 0 {
+		instanceClass := &DBaaSInstanceClass{}
+		if err := instanceWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: r.Spec.InstanceClassName}, instanceClass); err != nil {
+			if errors.IsNotFound(err) {
+				// the controller reports the missing instance class
+				return nil
+			}
+			return err
+		}
+		spec = instanceClass.MergeInstanceSpec(spec)
+	}
+	inventory := &DBaaSInventory{}
+	if err := instanceWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Namespace: spec.InventoryRef.Namespace, Name: spec.InventoryRef.Name}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			// the controller reports the missing inventory
+			return nil
+		}
+		return err
+	}
+	policy, err := GetInventoryPolicy(context.TODO(), instanceWebhookAPIClient, inventory)
+	if err != nil {
+		return err
+	}
+	if err := policy.ValidateInstance(spec); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateInventoryRef checks that the instance references an inventory, either directly or through the default
+// inventory of its instance class
+func (r *DBaaSInstance) validateInventoryRef() error {
+	if len(r.Spec.InventoryRef.Name) > 0 {
+		return nil
+	}
+	path := field.NewPath("spec").Child("inventoryRef")
+	if len(r.Spec.InstanceClassName) == 0 {
+		return field.Required(path, "inventoryRef must be set when no instanceClassName is set")
+	}
+	instanceClass := &DBaaSInstanceClass{}
+	if err := instanceWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: r.Spec.InstanceClassName}, instanceClass); err != nil {
+		if errors.IsNotFound(err) {
+			return field.NotFound(field.NewPath("spec").Child("instanceClassName"), r.Spec.InstanceClassName)
+		}
+		return err
+	}
+	if instanceClass.Spec.InventoryRef == nil || len(instanceClass.Spec.InventoryRef.Name) == 0 {
+		return field.Required(path, fmt.Sprintf("inventoryRef must be set, instance class %s has no default inventory", instanceClass.Name))
+	}
+	return nil
+}
+
+// validateExpiration checks that the instance sets at most one of a time to live and an expiration time
+func (r *DBaaSInstance) validateExpiration() error {
+	if r.Spec.TTL != nil && r.Spec.ExpirationTime != nil {
+		return field.Invalid(field.NewPath("spec").Child("ttl"), r.Spec.TTL.Duration.String(), "ttl and expirationTime cannot be set together")
+	}
+	return nil
+}
+
+// validateCloneSource checks that the clone source lives in the same inventory as the instance,
+// and that the namespace of the instance is allowed to read it
+func (r *DBaaSInstance) validateCloneSource() error {
+	source := r.Spec.CloneSource
+	if source == nil {
+		return nil
+	}
+	sourcePath := field.NewPath("spec").Child("cloneSource")
+	if (source.InstanceRef == nil) == (len(source.InstanceID) == 0) {
+		return field.Invalid(sourcePath, source, "exactly one of instanceRef or instanceID must be set")
+	}
+	inventory, provider, err := getInstanceProvider(r)
+	if err != nil || provider == nil {
+		return err
+	}
+	if !provider.Spec.AllowsClone {
+		return field.Invalid(sourcePath, source, fmt.Sprintf("provider %s does not support cloning instances", provider.Name))
+	}
+
+	if source.InstanceRef != nil {
+		sourceInstance := &DBaaSInstance{}
+		if err := instanceWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: source.InstanceRef.Name, Namespace: source.InstanceRef.Namespace}, sourceInstance); err != nil {
+			if errors.IsNotFound(err) {
+				return field.Invalid(sourcePath.Child("instanceRef"), source.InstanceRef, "source instance not found")
+			}
+			return err
+		}
+		sourceInventoryRef, err := getInstanceInventoryRef(sourceInstance)
+		if err != nil {
+			return err
+		}
+		if sourceInventoryRef == nil || sourceInventoryRef.Name != inventory.Name || sourceInventoryRef.Namespace != inventory.Namespace {
+			return field.Invalid(sourcePath.Child("instanceRef"), source.InstanceRef, "source instance must use the same inventory as the instance")
+		}
+	} else {
+		found, err := hasInventoryInstance(instanceWebhookAPIClient, inventory, source.InstanceID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return field.Invalid(sourcePath.Child("instanceID"), source.InstanceID, "source instance not found or hidden in the inventory")
+		}
+	}
+
+	validNS, err := IsValidConnectionNamespace(context.TODO(), instanceWebhookAPIClient, r.Namespace, inventory)
+	if err != nil {
+		return err
+	}
+	if !validNS {
+		return field.Invalid(sourcePath, source, fmt.Sprintf("namespace %s is not allowed to read instances of the inventory", r.Namespace))
+	}
+	return nil
+}
+
+// validatePowerState checks that the provider can pause the instance if its power state or power schedules request it
+func (r *DBaaSInstance) validatePowerState() error {
+	for i, powerSchedule := range r.Spec.PowerSchedules {
+		if _, err := util.ParseSchedule(powerSchedule.Schedule); err != nil {
+			return field.Invalid(field.NewPath("spec").Child("powerSchedules").Index(i).Child("schedule"), powerSchedule.Schedule, err.Error())
+		}
+	}
+	pausePath := field.NewPath("spec").Child("powerState")
+	pause := r.Spec.PowerState == InstancePowerStatePaused
+	for i, powerSchedule := range r.Spec.PowerSchedules {
+		if powerSchedule.PowerState == InstancePowerStatePaused {
+			pausePath = field.NewPath("spec").Child("powerSchedules").Index(i).Child("powerState")
+			pause = true
+			break
+		}
+	}
+	if !pause {
+		return nil
+	}
+	_, provider, err := getInstanceProvider(r)
+	if err != nil || provider == nil {
+		return err
+	}
+	if !provider.Spec.AllowsPause {
+		return field.Invalid(pausePath, InstancePowerStatePaused, fmt.Sprintf("provider %s does not support pausing instances", provider.Name))
+	}
+	return nil
+}
+
+// get the inventory and provider of an instance. returns nil if either does not exist, the controller reports them.
+func getInstanceProvider(instance *DBaaSInstance) (*DBaaSInventory, *DBaaSProvider, error) {
+	inventoryRef, err := getInstanceInventoryRef(instance)
+	if err != nil || inventoryRef == nil {
+		return nil, nil, err
+	}
+	inventory := &DBaaSInventory{}
+	if err := instanceWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inventoryRef.Name, Namespace: inventoryRef.Namespace}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	provider := &DBaaSProvider{}
+	if err := instanceWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inventory.Spec.ProviderRef.Name}, provider); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return inventory, provider, nil
+}
+
+// hasInventoryInstance checks whether an instance ID is among the discovered instances of an inventory,
+// and not hidden by its instance filter. inventories omitting the instances from their status are looked up
+// in their DBaaSDiscoveredInstances.
+func hasInventoryInstance(apiClient client.Client, inventory *DBaaSInventory, instanceID string) (bool, error) {
+	if inventory.Spec.OmitStatusInstances {
+		discovered := &DBaaSDiscoveredInstance{}
+		if err := apiClient.Get(context.TODO(), types.NamespacedName{Name: DiscoveredInstanceName(inventory.Name, instanceID), Namespace: inventory.Namespace}, discovered); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return discovered.Spec.InstanceID == instanceID && inventory.Spec.InstanceFilter.Matches(discovered.Spec.Instance), nil
+	}
+	for _, instance := range inventory.Status.Instances {
+		if instance.InstanceID == instanceID {
+			return inventory.Spec.InstanceFilter.Matches(instance), nil
+		}
+	}
+	return false, nil
+}
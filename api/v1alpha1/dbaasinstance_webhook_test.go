@@ -0,0 +1,348 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("DBaaSInstance Webhook", func() {
+	Context("without an inventory", func() {
+		instance := &DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-no-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceSpec{
+				Name: "test-instance-no-inventory",
+			},
+		}
+		instanceClass := &DBaaSInstanceClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-instance-class-no-inventory",
+			},
+			Spec: DBaaSInstanceClassSpec{
+				ProviderRef: NamespacedName{Name: testProviderName},
+			},
+		}
+		BeforeEach(assertResourceCreation(instanceClass))
+		AfterEach(assertResourceDeletion(instanceClass))
+
+		It("should not allow an instance without inventoryRef and instance class", func() {
+			Expect(k8sClient.Create(ctx, instance.DeepCopy())).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.inventoryRef: Required value: inventoryRef must be set when no instanceClassName is set"))
+		})
+
+		It("should not allow an instance whose instance class has no default inventory", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Spec.InstanceClassName = instanceClass.Name
+			Expect(k8sClient.Create(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.inventoryRef: Required value: inventoryRef must be set, instance class " + instanceClass.Name + " has no default inventory"))
+		})
+
+		It("should not allow an instance whose instance class does not exist", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Spec.InstanceClassName = "test-missing-instance-class"
+			Expect(k8sClient.Create(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.instanceClassName: Not found: \"test-missing-instance-class\""))
+		})
+	})
+
+	Context("with a namespace quota on the inventory", func() {
+		inventory := testDBaaSInventory.DeepCopy()
+		inventory.Name = "test-inventory-quota"
+		inventory.Spec.MaxInstancesPerNamespace = pointer.Int32Ptr(1)
+		instance := &DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-quota",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceSpec{
+				InventoryRef: NamespacedName{
+					Name:      inventory.Name,
+					Namespace: testNamespace,
+				},
+				Name: "test-instance-quota",
+			},
+		}
+		BeforeEach(assertResourceCreation(&testProvider))
+		BeforeEach(assertResourceCreation(&testSecret))
+		BeforeEach(assertResourceCreation(inventory))
+		BeforeEach(assertResourceCreation(instance))
+		AfterEach(assertResourceDeletion(instance))
+		AfterEach(assertResourceDeletion(inventory))
+		AfterEach(assertResourceDeletion(&testSecret))
+		AfterEach(assertResourceDeletion(&testProvider))
+
+		It("should not allow creating instances above the limit", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Name = "test-instance-quota-2"
+			instance2.SetResourceVersion("")
+			Eventually(func() error {
+				return k8sClient.Create(ctx, instance2)
+			}, timeout, interval).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"namespace default has reached the limit of 1 instances for the provider account test-inventory-quota in namespace default"))
+		})
+
+		It("should not allow moving an instance to an inventory above the limit", func() {
+			inventory2 := inventory.DeepCopy()
+			inventory2.Name = "test-inventory-quota-2"
+			inventory2.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, inventory2)).Should(Succeed())
+			defer assertResourceDeletion(inventory2)()
+			instance2 := instance.DeepCopy()
+			instance2.Name = "test-instance-quota-2"
+			instance2.Spec.InventoryRef.Name = inventory2.Name
+			instance2.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, instance2)).Should(Succeed())
+			defer assertResourceDeletion(instance2)()
+
+			Eventually(func() error {
+				moved := &DBaaSInstance{}
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(instance2), moved); err != nil {
+					return err
+				}
+				moved.Spec.InventoryRef.Name = inventory.Name
+				return k8sClient.Update(ctx, moved)
+			}, timeout, interval).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"namespace default has reached the limit of 1 instances for the provider account test-inventory-quota in namespace default"))
+		})
+
+		It("should count the instances referencing the inventory without its namespace", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Name = "test-instance-quota-2"
+			instance2.Spec.InventoryRef.Namespace = ""
+			instance2.SetResourceVersion("")
+			Eventually(func() error {
+				return k8sClient.Create(ctx, instance2)
+			}, timeout, interval).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"namespace default has reached the limit of 1 instances for the provider account test-inventory-quota in namespace default"))
+		})
+	})
+
+	Context("with cloud and parameter constraints on the inventory", func() {
+		inventory := testDBaaSInventory.DeepCopy()
+		inventory.Name = "test-inventory-constraints"
+		inventory.Spec.AllowedCloudProviders = &[]string{"AWS"}
+		inventory.Spec.AllowedCloudRegions = &[]string{"us-east-1", "eu-west-1"}
+		inventory.Spec.InstanceParamConstraints = []DBaaSInstanceParamConstraint{
+			{Name: "instanceSize", AllowedValues: []string{"M10", "M20"}},
+			{Name: "storageGB", Minimum: pointer.Int64Ptr(10), Maximum: pointer.Int64Ptr(100)},
+		}
+		instance := &DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-constraints",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceSpec{
+				InventoryRef: NamespacedName{
+					Name:      inventory.Name,
+					Namespace: testNamespace,
+				},
+				Name:          "test-instance-constraints",
+				CloudProvider: "AWS",
+				CloudRegion:   "us-east-1",
+				OtherInstanceParams: map[string]string{
+					"instanceSize": "M10",
+					"storageGB":    "50",
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(&testProvider))
+		BeforeEach(assertResourceCreation(&testSecret))
+		BeforeEach(assertResourceCreation(inventory))
+		BeforeEach(assertResourceCreation(instance))
+		AfterEach(assertResourceDeletion(instance))
+		AfterEach(assertResourceDeletion(inventory))
+		AfterEach(assertResourceDeletion(&testSecret))
+		AfterEach(assertResourceDeletion(&testProvider))
+
+		It("should not allow a cloud region outside of the allowed regions", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Name = "test-instance-constraints-2"
+			instance2.SetResourceVersion("")
+			instance2.Spec.CloudRegion = "ap-south-1"
+			Expect(k8sClient.Create(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.cloudRegion: Unsupported value: \"ap-south-1\": supported values: \"us-east-1\", \"eu-west-1\""))
+		})
+
+		It("should not allow a parameter above its maximum", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Name = "test-instance-constraints-2"
+			instance2.SetResourceVersion("")
+			instance2.Spec.OtherInstanceParams["storageGB"] = "500"
+			Expect(k8sClient.Create(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.otherInstanceParams[storageGB]: Invalid value: \"500\": must be less than or equal to 100"))
+		})
+
+		It("should not allow omitting a constrained parameter", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Name = "test-instance-constraints-2"
+			instance2.SetResourceVersion("")
+			delete(instance2.Spec.OtherInstanceParams, "storageGB")
+			Expect(k8sClient.Create(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.otherInstanceParams[storageGB]: Required value: must be set, the parameter is constrained by the policy"))
+		})
+
+		It("should not allow updating a parameter to a value that is not allowed", func() {
+			instance2 := &DBaaSInstance{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), instance2)).Should(Succeed())
+			instance2.Spec.OtherInstanceParams["instanceSize"] = "M80"
+			Expect(k8sClient.Update(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.otherInstanceParams[instanceSize]: Unsupported value: \"M80\": supported values: \"M10\", \"M20\""))
+		})
+	})
+
+	Context("with a clone source", func() {
+		inventory := testDBaaSInventory.DeepCopy()
+		inventory.Name = "test-inventory-clone"
+		instance := &DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-clone",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceSpec{
+				InventoryRef: NamespacedName{
+					Name:      inventory.Name,
+					Namespace: testNamespace,
+				},
+				Name: "test-instance-clone",
+				CloneSource: &DBaaSInstanceCloneSource{
+					InstanceID: "test-instance-id",
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(&testProvider))
+		BeforeEach(assertResourceCreation(&testSecret))
+		BeforeEach(assertResourceCreation(inventory))
+		AfterEach(assertResourceDeletion(inventory))
+		AfterEach(assertResourceDeletion(&testSecret))
+		AfterEach(assertResourceDeletion(&testProvider))
+
+		It("should not allow cloning with a provider that does not support it", func() {
+			Expect(k8sClient.Create(ctx, instance.DeepCopy())).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.cloneSource: Invalid value: \"object\": provider " + testProviderName + " does not support cloning instances"))
+		})
+
+		It("should not allow setting both an instance reference and an instance ID", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Spec.CloneSource.InstanceRef = &NamespacedName{Name: "test-source", Namespace: testNamespace}
+			Expect(k8sClient.Create(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.cloneSource: Invalid value: \"object\": exactly one of instanceRef or instanceID must be set"))
+		})
+
+		It("should not allow changing the clone source", func() {
+			instance2 := instance.DeepCopy()
+			instance2.Name = "test-instance-clone-update"
+			instance2.Spec.CloneSource = nil
+			Expect(k8sClient.Create(ctx, instance2)).Should(Succeed())
+			defer assertResourceDeletion(instance2)()
+			instance2.Spec.CloneSource = &DBaaSInstanceCloneSource{InstanceID: "test-instance-id"}
+			Expect(k8sClient.Update(ctx, instance2)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.cloneSource: Invalid value: \"object\": cloneSource is immutable"))
+		})
+	})
+
+	Context("with an expiration", func() {
+		It("should not allow setting both a time to live and an expiration time", func() {
+			expirationTime := metav1.NewTime(time.Now().Add(time.Hour))
+			instance := &DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-instance-expiration",
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSInstanceSpec{
+					InventoryRef: NamespacedName{
+						Name:      testDBaaSInventory.Name,
+						Namespace: testNamespace,
+					},
+					Name:           "test-instance-expiration",
+					TTL:            &metav1.Duration{Duration: time.Hour},
+					ExpirationTime: &expirationTime,
+				},
+			}
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.ttl: Invalid value: \"1h0m0s\": ttl and expirationTime cannot be set together"))
+		})
+	})
+
+	Context("with a paused power state", func() {
+		inventory := testDBaaSInventory.DeepCopy()
+		inventory.Name = "test-inventory-pause"
+		BeforeEach(assertResourceCreation(&testProvider))
+		BeforeEach(assertResourceCreation(&testSecret))
+		BeforeEach(assertResourceCreation(inventory))
+		AfterEach(assertResourceDeletion(inventory))
+		AfterEach(assertResourceDeletion(&testSecret))
+		AfterEach(assertResourceDeletion(&testProvider))
+
+		It("should not allow pausing with a provider that does not support it", func() {
+			instance := &DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-instance-pause",
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSInstanceSpec{
+					InventoryRef: NamespacedName{
+						Name:      inventory.Name,
+						Namespace: testNamespace,
+					},
+					Name: "test-instance-pause",
+					PowerSchedules: []DBaaSInstancePowerSchedule{
+						{
+							Schedule:   "0 20 * * 1-5",
+							PowerState: InstancePowerStatePaused,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.powerSchedules[0].powerState: Invalid value: \"Paused\": provider " + testProviderName + " does not support pausing instances"))
+		})
+
+		It("should not allow an invalid power schedule", func() {
+			instance := &DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-instance-schedule",
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSInstanceSpec{
+					InventoryRef: NamespacedName{
+						Name:      inventory.Name,
+						Namespace: testNamespace,
+					},
+					Name: "test-instance-schedule",
+					PowerSchedules: []DBaaSInstancePowerSchedule{
+						{
+							Schedule:   "0 20 30 2 *",
+							PowerState: InstancePowerStateRunning,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.powerSchedules[0].schedule: Invalid value: \"0 20 30 2 *\": schedule \"0 20 30 2 *\" never activates"))
+		})
+	})
+})
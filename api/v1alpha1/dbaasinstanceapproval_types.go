@@ -0,0 +1,86 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBaaSInstanceApprovalDecision defines the decisions of an instance approval
+type DBaaSInstanceApprovalDecision string
+
+// Constants for the decisions of an instance approval
+const (
+	InstanceApprovalApproved DBaaSInstanceApprovalDecision = "Approved"
+	InstanceApprovalRejected DBaaSInstanceApprovalDecision = "Rejected"
+)
+
+// DBaaSInstanceApprovalSpec defines the decision of an approver on the provisioning of a DBaaSInstance
+type DBaaSInstanceApprovalSpec struct {
+	// The DBaaSInstance awaiting approval, in the namespace of the approval
+	InstanceRef LocalObjectReference `json:"instanceRef"`
+
+	// +kubebuilder:validation:Enum=Approved;Rejected
+	// The decision of the approver
+	Decision DBaaSInstanceApprovalDecision `json:"decision"`
+
+	// The reason of the decision
+	Message string `json:"message,omitempty"`
+
+	// The user who created the approval, set by the operator on admission
+	Approver string `json:"approver,omitempty"`
+
+	// The groups of the user who created the approval, set by the operator on admission
+	ApproverGroups []string `json:"approverGroups,omitempty"`
+}
+
+// DBaaSInstanceApprovalStatus defines the observed state of DBaaSInstanceApproval
+type DBaaSInstanceApprovalStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.instanceRef.name`
+//+kubebuilder:printcolumn:name="Decision",type=string,JSONPath=`.spec.decision`
+//+kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.spec.approver`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DBaaSInstanceApproval is the Schema for the dbaasinstanceapprovals API. It approves or rejects the provisioning
+// of a DBaaSInstance whose inventory policy requires approval. Only the users allowed the "approve" verb on the
+// DBaaSInstance can create approvals, and approvals cannot be modified. The operator checks again that the approver
+// is allowed to approve the DBaaSInstance before provisioning it.
+//+operator-sdk:csv:customresourcedefinitions:displayName="DBaaSInstanceApproval"
+type DBaaSInstanceApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInstanceApprovalSpec   `json:"spec,omitempty"`
+	Status DBaaSInstanceApprovalStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSInstanceApprovalList contains a list of DBaaSInstanceApproval
+type DBaaSInstanceApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSInstanceApproval `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSInstanceApproval{}, &DBaaSInstanceApprovalList{})
+}
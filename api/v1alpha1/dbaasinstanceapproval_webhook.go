@@ -0,0 +1,190 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var dbaasinstanceapprovallog = logf.Log.WithName("dbaasinstanceapproval-resource")
+
+// the paths of the approval webhooks, which need the identity of the requesting user and so are not a
+// webhook.Defaulter and webhook.Validator
+const (
+	instanceApprovalMutatingWebhookPath   = "/mutate-dbaas-redhat-com-v1alpha1-dbaasinstanceapproval"
+	instanceApprovalValidatingWebhookPath = "/validate-dbaas-redhat-com-v1alpha1-dbaasinstanceapproval"
+)
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSInstanceApproval) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(instanceApprovalMutatingWebhookPath, &webhook.Admission{
+		Handler: &instanceApprovalDefaulter{},
+	})
+	mgr.GetWebhookServer().Register(instanceApprovalValidatingWebhookPath, &webhook.Admission{
+		Handler: &instanceApprovalValidator{apiClient: mgr.GetClient(), apiReader: mgr.GetAPIReader()},
+	})
+	return nil
+}
+
+//+kubebuilder:webhook:path=/mutate-dbaas-redhat-com-v1alpha1-dbaasinstanceapproval,mutating=true,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstanceapprovals,verbs=create,versions=v1alpha1,name=mdbaasinstanceapproval.kb.io,admissionReviewVersions=v1
+
+// instanceApprovalDefaulter records the requesting user as approver
+type instanceApprovalDefaulter struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &instanceApprovalDefaulter{}
+var _ admission.DecoderInjector = &instanceApprovalDefaulter{}
+
+// InjectDecoder implements admission.DecoderInjector
+func (h *instanceApprovalDefaulter) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler
+func (h *instanceApprovalDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	approval := &DBaaSInstanceApproval{}
+	if err := h.decoder.Decode(req, approval); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	dbaasinstanceapprovallog.Info("default", "name", approval.Name, "user", req.UserInfo.Username)
+
+	// the approver is the requesting user, whatever the request sets
+	approval.Spec.Approver = req.UserInfo.Username
+	approval.Spec.ApproverGroups = req.UserInfo.Groups
+	marshaled, err := json.Marshal(approval)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasinstanceapproval,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstanceapprovals,verbs=create;update,versions=v1alpha1,name=vdbaasinstanceapproval.kb.io,admissionReviewVersions=v1
+
+// instanceApprovalValidator checks that the requesting user is the approver and is allowed to approve the instance,
+// and that the spec of the approvals is immutable
+type instanceApprovalValidator struct {
+	apiClient client.Client
+	// reads the instances uncached, they are usually approved right after their creation
+	apiReader client.Reader
+	decoder   *admission.Decoder
+}
+
+var _ admission.Handler = &instanceApprovalValidator{}
+var _ admission.DecoderInjector = &instanceApprovalValidator{}
+
+// InjectDecoder implements admission.DecoderInjector
+func (h *instanceApprovalValidator) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler
+func (h *instanceApprovalValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	approval := &DBaaSInstanceApproval{}
+	if err := h.decoder.Decode(req, approval); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	dbaasinstanceapprovallog.Info("validate "+string(req.Operation), "name", approval.Name, "user", req.UserInfo.Username)
+
+	if req.Operation == admissionv1.Update {
+		oldApproval := &DBaaSInstanceApproval{}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldApproval); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !reflect.DeepEqual(approval.Spec, oldApproval.Spec) {
+			return admission.Denied("the spec of an approval cannot be modified, create a new approval instead")
+		}
+		return admission.Allowed("")
+	}
+
+	// the approver is recorded by the mutating webhook, check that no other webhook modified it
+	if approval.Spec.Approver != req.UserInfo.Username || !sameStrings(approval.Spec.ApproverGroups, req.UserInfo.Groups) {
+		return admission.Denied(fmt.Sprintf("the approver must be the requesting user %s", req.UserInfo.Username))
+	}
+	instance := &DBaaSInstance{}
+	if err := h.apiReader.Get(ctx, types.NamespacedName{Namespace: approval.Namespace, Name: approval.Spec.InstanceRef.Name}, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return admission.Denied(fmt.Sprintf("DBaaSInstance %s not found in namespace %s", approval.Spec.InstanceRef.Name, approval.Namespace))
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	if allowed, err := CanApproveInstance(ctx, h.apiClient, instance, req.UserInfo.Username, req.UserInfo.Groups, extra); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	} else if !allowed {
+		return admission.Denied(fmt.Sprintf("user %s is not allowed to %s DBaaSInstance %s in namespace %s",
+			req.UserInfo.Username, ApproveVerb, instance.Name, instance.Namespace))
+	}
+	return admission.Allowed("")
+}
+
+// CanApproveInstance checks with a SubjectAccessReview that a user is allowed the approve verb on an instance
+func CanApproveInstance(ctx context.Context, c client.Client, instance *DBaaSInstance, user string, groups []string,
+	extra map[string]authorizationv1.ExtraValue) (bool, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: instance.Namespace,
+				Verb:      ApproveVerb,
+				Group:     GroupVersion.Group,
+				Version:   GroupVersion.Version,
+				Resource:  "dbaasinstances",
+				Name:      instance.Name,
+			},
+			User:   user,
+			Groups: groups,
+			Extra:  extra,
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// sameStrings checks whether two lists hold the same values in the same order, an empty list being equal to nil
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
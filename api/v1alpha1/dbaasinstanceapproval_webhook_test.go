@@ -0,0 +1,98 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("DBaaSInstanceApproval Webhook", func() {
+	Context("with a DBaaSInstance", func() {
+		inventory := testDBaaSInventory.DeepCopy()
+		inventory.Name = "test-inventory-approval"
+		instance := &DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-approval",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceSpec{
+				InventoryRef: NamespacedName{
+					Name:      inventory.Name,
+					Namespace: testNamespace,
+				},
+				Name: "test-instance-approval",
+			},
+		}
+		approval := &DBaaSInstanceApproval{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-approval",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceApprovalSpec{
+				InstanceRef: LocalObjectReference{Name: instance.Name},
+				Decision:    InstanceApprovalApproved,
+				Approver:    "someone-else",
+			},
+		}
+		BeforeEach(assertResourceCreation(&testProvider))
+		BeforeEach(assertResourceCreation(&testSecret))
+		BeforeEach(assertResourceCreation(inventory))
+		BeforeEach(assertResourceCreation(instance))
+		BeforeEach(assertResourceCreation(approval))
+		AfterEach(assertResourceDeletion(approval))
+		AfterEach(assertResourceDeletion(instance))
+		AfterEach(assertResourceDeletion(inventory))
+		AfterEach(assertResourceDeletion(&testSecret))
+		AfterEach(assertResourceDeletion(&testProvider))
+
+		It("should record the requesting user as approver", func() {
+			created := &DBaaSInstanceApproval{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(approval), created)).Should(Succeed())
+			Expect(created.Spec.Approver).ShouldNot(BeEmpty())
+			Expect(created.Spec.Approver).ShouldNot(Equal("someone-else"))
+		})
+
+		It("should not allow changing the approver", func() {
+			updated := &DBaaSInstanceApproval{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(approval), updated)).Should(Succeed())
+			updated.Spec.Approver = "someone-else"
+			Expect(k8sClient.Update(ctx, updated)).Should(MatchError("admission webhook \"vdbaasinstanceapproval.kb.io\" denied the request: " +
+				"the spec of an approval cannot be modified, create a new approval instead"))
+		})
+
+		It("should not allow changing the decision", func() {
+			updated := &DBaaSInstanceApproval{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(approval), updated)).Should(Succeed())
+			updated.Spec.Decision = InstanceApprovalRejected
+			Expect(k8sClient.Update(ctx, updated)).Should(MatchError("admission webhook \"vdbaasinstanceapproval.kb.io\" denied the request: " +
+				"the spec of an approval cannot be modified, create a new approval instead"))
+		})
+
+		It("should not allow approving a missing instance", func() {
+			approval2 := approval.DeepCopy()
+			approval2.Name = "test-instance-approval-2"
+			approval2.SetResourceVersion("")
+			approval2.Spec.InstanceRef.Name = "test-missing-instance"
+			Expect(k8sClient.Create(ctx, approval2)).Should(MatchError("admission webhook \"vdbaasinstanceapproval.kb.io\" denied the request: " +
+				"DBaaSInstance test-missing-instance not found in namespace " + testNamespace))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBaaSInstanceClassSpec defines the provisioning presets of a DBaaSInstanceClass
+type DBaaSInstanceClassSpec struct {
+	// A reference to the DBaaSProvider CR that this class can be used with
+	ProviderRef NamespacedName `json:"providerRef"`
+
+	// A reference to the DBaaSInventory CR used by instances of this class that do not set their own inventoryRef
+	InventoryRef *NamespacedName `json:"inventoryRef,omitempty"`
+
+	// Identifies the desired cloud infrastructure provider
+	CloudProvider string `json:"cloudProvider,omitempty"`
+
+	// Identifies the requested deployment region within the cloud provider (e.g. us-east-1)
+	CloudRegion string `json:"cloudRegion,omitempty"`
+
+	// Any other provider-specific parameters related to the instance provisioning
+	OtherInstanceParams map[string]string `json:"otherInstanceParams,omitempty"`
+}
+
+// DBaaSInstanceClassStatus defines the observed state of DBaaSInstanceClass
+type DBaaSInstanceClassStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.providerRef.name`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DBaaSInstanceClass is the Schema for the dbaasinstanceclasses API. An instance class holds reusable
+// provisioning presets for a provider. DBaaSInstances referencing the class override its presets.
+//+operator-sdk:csv:customresourcedefinitions:displayName="DBaaSInstanceClass"
+type DBaaSInstanceClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInstanceClassSpec   `json:"spec,omitempty"`
+	Status DBaaSInstanceClassStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSInstanceClassList contains a list of DBaaSInstanceClass
+type DBaaSInstanceClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSInstanceClass `json:"items"`
+}
+
+// MergeInstanceSpec merges the presets of the instance class with an instance spec. Fields set on the instance take precedence.
+func (c *DBaaSInstanceClass) MergeInstanceSpec(spec *DBaaSInstanceSpec) *DBaaSInstanceSpec {
+	merged := spec.DeepCopy()
+	merged.InstanceClassName = ""
+	if len(merged.InventoryRef.Name) == 0 && c.Spec.InventoryRef != nil {
+		merged.InventoryRef = *c.Spec.InventoryRef
+	}
+	if len(merged.CloudProvider) == 0 {
+		merged.CloudProvider = c.Spec.CloudProvider
+	}
+	if len(merged.CloudRegion) == 0 {
+		merged.CloudRegion = c.Spec.CloudRegion
+	}
+	if len(c.Spec.OtherInstanceParams) > 0 {
+		params := make(map[string]string, len(c.Spec.OtherInstanceParams)+len(spec.OtherInstanceParams))
+		for k, v := range c.Spec.OtherInstanceParams {
+			params[k] = v
+		}
+		for k, v := range spec.OtherInstanceParams {
+			params[k] = v
+		}
+		merged.OtherInstanceParams = params
+	}
+	return merged
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSInstanceClass{}, &DBaaSInstanceClassList{})
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,8 +33,108 @@ type DBaaSOperatorInventorySpec struct {
 
 	// The policy for this inventory
 	DBaaSInventoryPolicy `json:",inline"`
+
+	// What happens to the DBaaSConnections and DBaaSInstances referencing this inventory when it is deleted.
+	// Block rejects the deletion while they exist, Orphan marks them with the InventoryDeleted condition,
+	// and Cascade deletes them. Defaults to Orphan.
+	// +kubebuilder:validation:Enum=Block;Orphan;Cascade
+	DeletionPolicy DBaaSInventoryDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Restricts the discovered instances exposed in the inventory status. Connections and instances
+	// can't reference the instances hidden by the filter.
+	InstanceFilter *DBaaSInventoryInstanceFilter `json:"instanceFilter,omitempty"`
+
+	// Leaves the list of instances out of the inventory status, for accounts with many instances.
+	// The instances are still listed as DBaaSDiscoveredInstances, and counted in the status.
+	OmitStatusInstances bool `json:"omitStatusInstances,omitempty"`
+
+	// An external source of the credentials. The operator creates the Secret named by CredentialsRef
+	// with the data of the source, and refreshes it periodically.
+	CredentialsSource *DBaaSCredentialsSource `json:"credentialsSource,omitempty"`
 }
 
+// DBaaSCredentialsSource defines an external source of inventory credentials. Exactly one source must be set.
+type DBaaSCredentialsSource struct {
+	// Credentials read from files mounted in the operator pod
+	File *FileCredentialsSource `json:"file,omitempty"`
+
+	// Credentials read from an HTTP secret service, whose host must be allowed by the operator
+	HTTP *HTTPCredentialsSource `json:"http,omitempty"`
+
+	// How often the operator reads the source to refresh the Secret, defaults to 5 minutes. The source is read
+	// at most once per interval, adding the refresh annotation reads it again.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// FileCredentialsSource reads the credentials from a directory, with one file per Secret key
+type FileCredentialsSource struct {
+	// The path of the directory, relative to the directory of the inventory namespace in the credentials directory
+	// of the operator
+	Path string `json:"path"`
+}
+
+// HTTPCredentialsSource reads the credentials from a JSON object of string values returned by an HTTP GET
+type HTTPCredentialsSource struct {
+	// The https URL of the credentials. Its host must be allowed by the credentials source hosts of the operator.
+	URL string `json:"url"`
+
+	// A key of a Secret in the namespace of the inventory holding the bearer token sent to the service
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// DBaaSInventoryInstanceFilter selects the discovered instances exposed by an inventory
+type DBaaSInventoryInstanceFilter struct {
+	// Glob patterns, such as "dev-*", matched against the instance names. If set, an instance is exposed
+	// only if its name matches one of the patterns.
+	NamePatterns []string `json:"namePatterns,omitempty"`
+
+	// Glob patterns matched against the instance names. An instance whose name matches one of the
+	// patterns is hidden.
+	ExcludeNamePatterns []string `json:"excludeNamePatterns,omitempty"`
+
+	// Key/value pairs the instance info must all match for an instance to be exposed
+	InstanceInfo map[string]string `json:"instanceInfo,omitempty"`
+}
+
+// Matches checks whether a discovered instance is exposed by the filter. A nil filter exposes every instance.
+func (f *DBaaSInventoryInstanceFilter) Matches(instance Instance) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.NamePatterns) > 0 && !matchesNamePattern(f.NamePatterns, instance.Name) {
+		return false
+	}
+	if matchesNamePattern(f.ExcludeNamePatterns, instance.Name) {
+		return false
+	}
+	for key, value := range f.InstanceInfo {
+		if v, ok := instance.InstanceInfo[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesNamePattern checks whether a name matches one of the glob patterns, invalid patterns are rejected by the webhook
+func matchesNamePattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// DBaaSInventoryDeletionPolicy defines what happens to the dependents of an inventory when it is deleted
+type DBaaSInventoryDeletionPolicy string
+
+// Constants for inventory deletion policies
+const (
+	InventoryDeletionPolicyBlock   DBaaSInventoryDeletionPolicy = "Block"
+	InventoryDeletionPolicyOrphan  DBaaSInventoryDeletionPolicy = "Orphan"
+	InventoryDeletionPolicyCascade DBaaSInventoryDeletionPolicy = "Cascade"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
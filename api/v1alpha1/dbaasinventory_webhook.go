@@ -18,9 +18,14 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"path"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,12 +39,96 @@ import (
 const (
 	rdsRegistration = "rds-registration"
 	providerNameKey = "spec.providerRef.name"
+
+	// InventoryRefKey indexes DBaaSConnections and DBaaSInstances by the inventory they resolve to, see InventoryRefIndexer.
+	// The index is registered by the DBaaSInventory controller, and on DBaaSInventoryAccessReviews by their controller.
+	InventoryRefKey = "spec.inventoryRef"
+
+	// maxListedDependents is the maximum number of dependents listed when an inventory deletion is rejected
+	maxListedDependents = 10
 )
 
+// InventoryRefIndexValue returns the value of the InventoryRefKey index for an inventory reference
+func InventoryRefIndexValue(inventoryRef NamespacedName) string {
+	return inventoryRef.Namespace + "/" + inventoryRef.Name
+}
+
+// InventoryRefIndexer returns the indexer function of the InventoryRefKey index. DBaaSConnections and DBaaSInstances are
+// indexed by the inventory they resolve to, see ResolveInventoryRef. An instance using the default inventory of its
+// DBaaSInstanceClass is indexed again on its next update, the instance controller updates its status when the class changes.
+func InventoryRefIndexer(reader client.Reader) client.IndexerFunc {
+	return func(obj client.Object) []string {
+		inventoryRef, err := ResolveInventoryRef(context.Background(), reader, obj)
+		if err != nil {
+			dbaasinventorylog.Error(err, "unable to resolve the inventory reference", "name", obj.GetName(), "namespace", obj.GetNamespace())
+			return nil
+		}
+		if inventoryRef == nil {
+			return nil
+		}
+		return []string{InventoryRefIndexValue(*inventoryRef)}
+	}
+}
+
+// ResolveInventoryRef returns the inventory a DBaaSConnection, DBaaSInstance or DBaaSInventoryAccessReview resolves to: the inventory reference of its spec,
+// defaulting to its namespace, or the default inventory of the DBaaSInstanceClass of an instance.
+// returns nil if no inventory is referenced or the instance class does not exist.
+func ResolveInventoryRef(ctx context.Context, reader client.Reader, obj client.Object) (*NamespacedName, error) {
+	var inventoryRef NamespacedName
+	switch v := obj.(type) {
+	case *DBaaSConnection:
+		inventoryRef = v.Spec.InventoryRef
+	case *DBaaSInventoryAccessReview:
+		inventoryRef = v.Spec.InventoryRef
+	case *DBaaSInstance:
+		inventoryRef = v.Spec.InventoryRef
+		if len(inventoryRef.Name) == 0 && len(v.Spec.InstanceClassName) > 0 {
+			instanceClass := &DBaaSInstanceClass{}
+			if err := reader.Get(ctx, types.NamespacedName{Name: v.Spec.InstanceClassName}, instanceClass); err != nil {
+				return nil, client.IgnoreNotFound(err)
+			}
+			if instanceClass.Spec.InventoryRef == nil {
+				return nil, nil
+			}
+			inventoryRef = *instanceClass.Spec.InventoryRef
+		}
+	default:
+		return nil, fmt.Errorf("%T does not reference an inventory", obj)
+	}
+	if len(inventoryRef.Name) == 0 {
+		return nil, nil
+	}
+	if len(inventoryRef.Namespace) == 0 {
+		inventoryRef.Namespace = obj.GetNamespace()
+	}
+	return &inventoryRef, nil
+}
+
 // log is for logging in this package.
 var dbaasinventorylog = logf.Log.WithName("dbaasinventory-resource")
 var inventoryWebhookAPIClient client.Client
 
+// CredentialsSourceAllowedHosts are the hosts that HTTP credentials sources may read from, set by the operator.
+// No HTTP credentials source is allowed if it is empty.
+var CredentialsSourceAllowedHosts []string
+
+// IsCredentialsSourceHostAllowed checks whether a host is one of the allowed hosts. An allowed host starting with "*."
+// allows the subdomains of its domain.
+func IsCredentialsSourceHostAllowed(host string, allowedHosts []string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+		} else if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 // SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSInventory) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	if inventoryWebhookAPIClient == nil {
@@ -57,7 +146,7 @@ func (r *DBaaSInventory) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
-//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasinventory,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinventories,verbs=create;update,versions=v1alpha1,name=vdbaasinventory.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasinventory,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinventories,verbs=create;update;delete,versions=v1alpha1,name=vdbaasinventory.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &DBaaSInventory{}
 
@@ -76,25 +165,85 @@ func (r *DBaaSInventory) ValidateUpdate(old runtime.Object) error {
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSInventory) ValidateDelete() error {
 	dbaasinventorylog.Info("validate delete", "name", r.Name)
+	if r.Spec.DeletionPolicy != InventoryDeletionPolicyBlock {
+		return nil
+	}
+	dependents, err := listInventoryDependents(r)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		listed := dependents
+		if len(listed) > maxListedDependents {
+			listed = listed[:maxListedDependents]
+		}
+		msg := fmt.Sprintf("provider account %s has %d dependents and its deletion policy is %s: %s",
+			r.Name, len(dependents), InventoryDeletionPolicyBlock, strings.Join(listed, ", "))
+		if len(dependents) > len(listed) {
+			msg += fmt.Sprintf(" and %d more", len(dependents)-len(listed))
+		}
+		return errors.New(msg)
+	}
 	return nil
 }
 
+// listInventoryDependents lists the DBaaSConnections and DBaaSInstances referencing an inventory, as "Kind namespace/name"
+func listInventoryDependents(inv *DBaaSInventory) ([]string, error) {
+	inventoryRef := client.MatchingFields{InventoryRefKey: InventoryRefIndexValue(NamespacedName{Name: inv.Name, Namespace: inv.Namespace})}
+	var dependents []string
+	connectionList := &DBaaSConnectionList{}
+	if err := inventoryWebhookAPIClient.List(context.TODO(), connectionList, inventoryRef); err != nil {
+		return nil, err
+	}
+	for _, connection := range connectionList.Items {
+		dependents = append(dependents, fmt.Sprintf("DBaaSConnection %s/%s", connection.Namespace, connection.Name))
+	}
+	instanceList := &DBaaSInstanceList{}
+	if err := inventoryWebhookAPIClient.List(context.TODO(), instanceList, inventoryRef); err != nil {
+		return nil, err
+	}
+	for _, instance := range instanceList.Items {
+		dependents = append(dependents, fmt.Sprintf("DBaaSInstance %s/%s", instance.Namespace, instance.Name))
+	}
+	return dependents, nil
+}
+
 func validateInventory(inv *DBaaSInventory, oldInv *DBaaSInventory) error {
 	// Provider name is immutable
 	if oldInv != nil && oldInv.Spec.ProviderRef.Name != inv.Spec.ProviderRef.Name {
 		msg := "provider name is immutable for provider accounts"
 		return field.Invalid(field.NewPath("spec").Child("providerRef").Child("name"), inv.Spec.ProviderRef.Name, msg)
 	}
-	// Retrieve the secret object
-	secret := &corev1.Secret{}
-	if err := inventoryWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inv.Spec.DBaaSInventorySpec.CredentialsRef.Name, Namespace: inv.Namespace}, secret); err != nil {
-		return err
+	if inv.Spec.CredentialsRef != nil && inv.Spec.WorkloadIdentity != nil {
+		return field.Invalid(field.NewPath("spec").Child("workloadIdentity"), *inv.Spec.WorkloadIdentity, "both credentialsRef and workloadIdentity are specified")
+	}
+	if inv.Spec.CredentialsRef == nil && inv.Spec.WorkloadIdentity == nil {
+		return field.Required(field.NewPath("spec").Child("credentialsRef"), "either credentialsRef or workloadIdentity must be specified")
+	}
+	// Retrieve the secret object, the secret of a credentials source is created by the operator
+	var secret *corev1.Secret
+	if inv.Spec.CredentialsSource != nil {
+		if err := validateCredentialsSource(inv); err != nil {
+			return err
+		}
+	} else if inv.Spec.CredentialsRef != nil {
+		secret = &corev1.Secret{}
+		if err := inventoryWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inv.Spec.DBaaSInventorySpec.CredentialsRef.Name, Namespace: inv.Namespace}, secret); err != nil {
+			return err
+		}
 	}
 	// Retrieve the provider object
 	provider := &DBaaSProvider{}
 	if err := inventoryWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inv.Spec.ProviderRef.Name, Namespace: ""}, provider); err != nil {
 		return err
 	}
+	if mode := inv.Spec.CredentialsMode(); !provider.Spec.SupportsCredentialsMode(mode) {
+		credentialsPath := field.NewPath("spec").Child("credentialsRef")
+		if mode == CredentialsModeWorkloadIdentity {
+			credentialsPath = field.NewPath("spec").Child("workloadIdentity")
+		}
+		return field.Invalid(credentialsPath, mode, fmt.Sprintf("provider %s does not support the %s credentials mode", provider.Name, mode))
+	}
 	// Check RDS
 	if oldInv == nil && inv.Spec.ProviderRef.Name == rdsRegistration {
 		if err := validateRDS(); err != nil {
@@ -107,9 +256,114 @@ func validateInventory(inv *DBaaSInventory, oldInv *DBaaSInventory) error {
 			return err
 		}
 	}
+	if err := validateParamConstraints(inv.Spec.InstanceParamConstraints, field.NewPath("spec").Child("instanceParamConstraints")); err != nil {
+		return err
+	}
+	// Check the bounds of the cluster policy
+	clusterPolicy, err := GetClusterPolicy(context.TODO(), inventoryWebhookAPIClient)
+	if err != nil {
+		return err
+	}
+	if clusterPolicy != nil {
+		if err := validatePolicyBounds(&inv.Spec.DBaaSInventoryPolicy, clusterPolicy.Spec.Bounds, field.NewPath("spec")); err != nil {
+			return err
+		}
+	}
+	// Check the provider lists, set by policies only
+	if inv.Spec.AllowedProviders != nil {
+		return field.Forbidden(field.NewPath("spec").Child("allowedProviders"), "allowed providers can only be set by policies")
+	}
+	if inv.Spec.DeniedProviders != nil {
+		return field.Forbidden(field.NewPath("spec").Child("deniedProviders"), "denied providers can only be set by policies")
+	}
+	if oldInv == nil {
+		policy, err := GetEffectivePolicy(context.TODO(), inventoryWebhookAPIClient, inv.Namespace)
+		if err != nil {
+			return err
+		}
+		if !InventoryPolicy(inv, policy, clusterPolicy).AllowsProvider(inv.Spec.ProviderRef.Name) {
+			return field.Invalid(field.NewPath("spec").Child("providerRef").Child("name"), inv.Spec.ProviderRef.Name,
+				fmt.Sprintf("provider is not allowed by the policy of namespace %s", inv.Namespace))
+		}
+	}
+	if err := validateInstanceFilter(inv.Spec.InstanceFilter); err != nil {
+		return err
+	}
+	if inv.Spec.WorkloadIdentity != nil {
+		return validateWorkloadIdentity(inv)
+	}
+	if secret == nil {
+		return nil
+	}
 	return validateInventoryMandatoryFields(inv, secret, provider)
 }
 
+// validateCredentialsSource checks that a credentials source has exactly one valid source, and a Secret to materialize
+func validateCredentialsSource(inv *DBaaSInventory) error {
+	source := inv.Spec.CredentialsSource
+	sourcePath := field.NewPath("spec").Child("credentialsSource")
+	if inv.Spec.CredentialsRef == nil || len(inv.Spec.CredentialsRef.Name) == 0 {
+		return field.Required(field.NewPath("spec").Child("credentialsRef"), "credentialsRef names the secret created from the credentials source")
+	}
+	if (source.File == nil) == (source.HTTP == nil) {
+		return field.Invalid(sourcePath, *source, "exactly one of file or http must be specified")
+	}
+	if source.File != nil {
+		filePath := source.File.Path
+		if len(filePath) == 0 || path.IsAbs(filePath) || path.Clean(filePath) == ".." || strings.HasPrefix(path.Clean(filePath), "../") {
+			return field.Invalid(sourcePath.Child("file").Child("path"), filePath,
+				fmt.Sprintf("path must be relative to the credentials directory of namespace %s", inv.Namespace))
+		}
+	}
+	if source.HTTP != nil {
+		urlPath := sourcePath.Child("http").Child("url")
+		u, err := url.Parse(source.HTTP.URL)
+		if err != nil || u.Scheme != "https" || len(u.Host) == 0 {
+			return field.Invalid(urlPath, source.HTTP.URL, "url must be an https URL")
+		}
+		if !IsCredentialsSourceHostAllowed(u.Hostname(), CredentialsSourceAllowedHosts) {
+			return field.Forbidden(urlPath, fmt.Sprintf("host %s is not an allowed credentials source host of the operator", u.Hostname()))
+		}
+	}
+	return nil
+}
+
+// validateWorkloadIdentity checks that the ServiceAccount of the workload identity exists in the namespace of the inventory
+func validateWorkloadIdentity(inv *DBaaSInventory) error {
+	identityPath := field.NewPath("spec").Child("workloadIdentity").Child("serviceAccountName")
+	name := inv.Spec.WorkloadIdentity.ServiceAccountName
+	if len(name) == 0 {
+		return field.Required(identityPath, "serviceAccountName is required")
+	}
+	serviceAccount := &corev1.ServiceAccount{}
+	if err := inventoryWebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: inv.Namespace}, serviceAccount); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.Invalid(identityPath, name, fmt.Sprintf("service account %s not found in namespace %s", name, inv.Namespace))
+		}
+		return err
+	}
+	return nil
+}
+
+// validateInstanceFilter checks the name patterns of an instance filter
+func validateInstanceFilter(filter *DBaaSInventoryInstanceFilter) error {
+	if filter == nil {
+		return nil
+	}
+	filterPath := field.NewPath("spec").Child("instanceFilter")
+	for i, pattern := range filter.NamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return field.Invalid(filterPath.Child("namePatterns").Index(i), pattern, err.Error())
+		}
+	}
+	for i, pattern := range filter.ExcludeNamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return field.Invalid(filterPath.Child("excludeNamePatterns").Index(i), pattern, err.Error())
+		}
+	}
+	return nil
+}
+
 func validateInventoryMandatoryFields(inv *DBaaSInventory, secret *corev1.Secret, provider *DBaaSProvider) error {
 	for _, credField := range provider.Spec.CredentialFields {
 		if credField.Required {
@@ -299,6 +299,64 @@ var _ = Describe("DBaaSInventory Webhook", func() {
 				err := k8sClient.Create(ctx, inv)
 				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: values: Invalid value: []string(nil): for 'in', 'notin' operators, values set can't be empty"))
 			})
+			It("invalid instance filter name pattern", func() {
+				inv := testDBaaSInventory.DeepCopy()
+				inv.Spec.ConnectionNsSelector = nil
+				inv.Spec.InstanceFilter = &DBaaSInventoryInstanceFilter{NamePatterns: []string{"dev-["}}
+				err := k8sClient.Create(ctx, inv)
+				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.instanceFilter.namePatterns[0]: Invalid value: \"dev-[\": syntax error in pattern"))
+			})
+			It("workload identity not supported by the provider", func() {
+				inv := testDBaaSInventory.DeepCopy()
+				inv.Spec.ConnectionNsSelector = nil
+				inv.Spec.CredentialsRef = nil
+				inv.Spec.WorkloadIdentity = &WorkloadIdentity{ServiceAccountName: "default"}
+				err := k8sClient.Create(ctx, inv)
+				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.workloadIdentity: Invalid value: \"WorkloadIdentity\": provider " +
+					testProviderName + " does not support the WorkloadIdentity credentials mode"))
+			})
+			It("both credentialsRef and workloadIdentity", func() {
+				inv := testDBaaSInventory.DeepCopy()
+				inv.Spec.ConnectionNsSelector = nil
+				inv.Spec.WorkloadIdentity = &WorkloadIdentity{ServiceAccountName: "default"}
+				err := k8sClient.Create(ctx, inv)
+				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.workloadIdentity: Invalid value: v1alpha1.WorkloadIdentity{ServiceAccountName:\"default\", Audience:\"\", Parameters:map[string]string(nil)}: both credentialsRef and workloadIdentity are specified"))
+			})
+			It("http credentials source host not allowed", func() {
+				inv := testDBaaSInventory.DeepCopy()
+				inv.Spec.ConnectionNsSelector = nil
+				inv.Spec.CredentialsSource = &DBaaSCredentialsSource{
+					HTTP: &HTTPCredentialsSource{URL: "https://vault.example.com/v1/atlas"},
+				}
+				err := k8sClient.Create(ctx, inv)
+				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.credentialsSource.http.url: Forbidden: host vault.example.com is not an allowed credentials source host of the operator"))
+			})
+			It("provider lists set on the inventory", func() {
+				inv := testDBaaSInventory.DeepCopy()
+				inv.Spec.ConnectionNsSelector = nil
+				inv.Spec.DeniedProviders = []string{rdsRegistration}
+				err := k8sClient.Create(ctx, inv)
+				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.deniedProviders: Forbidden: denied providers can only be set by policies"))
+			})
+			It("provider denied by the cluster policy", func() {
+				clusterPolicy := &ClusterDBaaSPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: ClusterDBaaSPolicyName},
+					Spec: ClusterDBaaSPolicySpec{
+						Bounds: &DBaaSInventoryPolicy{DeniedProviders: []string{testProviderName}},
+					},
+				}
+				Expect(k8sClient.Create(ctx, clusterPolicy)).Should(Succeed())
+				defer assertResourceDeletion(clusterPolicy)()
+				Eventually(func() error {
+					return inventoryWebhookAPIClient.Get(ctx, client.ObjectKeyFromObject(clusterPolicy), &ClusterDBaaSPolicy{})
+				}, timeout, interval).Should(Succeed())
+
+				inv := testDBaaSInventory.DeepCopy()
+				inv.Spec.ConnectionNsSelector = nil
+				err := k8sClient.Create(ctx, inv)
+				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.providerRef.name: Invalid value: \"" +
+					testProviderName + "\": provider is not allowed by the policy of namespace " + testNamespace))
+			})
 			It("missing required credential fields", func() {
 				err := k8sClient.Create(ctx, &testDBaaSInventory)
 				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.credentialsRef: Invalid value: v1alpha1.LocalObjectReference{Name:\"testsecret\"}: credentialsRef is invalid: field1 is required in secret testsecret"))
@@ -350,6 +408,64 @@ var _ = Describe("DBaaSInventory Webhook", func() {
 				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.providerRef.name: Invalid value: \"crunchy-registration\": provider name is immutable for provider accounts"))
 			})
 		})
+	Context("delete",
+		func() {
+			blockedInventory := testDBaaSInventory.DeepCopy()
+			blockedInventory.Name = "testinventory-blocked"
+			blockedInventory.Spec.DeletionPolicy = InventoryDeletionPolicyBlock
+			blockedConnection := testDBaaSConnection.DeepCopy()
+			blockedConnection.Name = "testconnection-blocked"
+			blockedConnection.Spec.InventoryRef.Name = blockedInventory.Name
+			BeforeEach(assertResourceCreation(&testSecret))
+			BeforeEach(assertResourceCreation(&testProvider))
+			BeforeEach(assertResourceCreation(blockedInventory))
+			AfterEach(assertResourceDeletion(&testProvider))
+			AfterEach(assertResourceDeletion(&testSecret))
+			It("should block deletion with dependents", func() {
+				setInventoryReady(blockedInventory, blockedConnection.Spec.InstanceID)
+				assertResourceCreation(blockedConnection)()
+				Eventually(func() error {
+					return k8sClient.Delete(ctx, blockedInventory)
+				}, timeout, interval).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: " +
+					"provider account testinventory-blocked has 1 dependents and its deletion policy is Block: DBaaSConnection default/testconnection-blocked"))
+				assertResourceDeletion(blockedConnection)()
+				Eventually(func() error {
+					return k8sClient.Delete(ctx, blockedInventory)
+				}, timeout, interval).Should(Succeed())
+			})
+			It("should block deletion with instances using the inventory of their instance class", func() {
+				instanceClass := &DBaaSInstanceClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testinstanceclass-blocked",
+					},
+					Spec: DBaaSInstanceClassSpec{
+						ProviderRef:  NamespacedName{Name: testProviderName},
+						InventoryRef: &NamespacedName{Name: blockedInventory.Name, Namespace: blockedInventory.Namespace},
+					},
+				}
+				instance := &DBaaSInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testinstance-blocked",
+						Namespace: testNamespace,
+					},
+					Spec: DBaaSInstanceSpec{
+						InstanceClassName: instanceClass.Name,
+						Name:              "testinstance-blocked",
+					},
+				}
+				assertResourceCreation(instanceClass)()
+				defer assertResourceDeletion(instanceClass)()
+				assertResourceCreation(instance)()
+				Eventually(func() error {
+					return k8sClient.Delete(ctx, blockedInventory)
+				}, timeout, interval).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: " +
+					"provider account testinventory-blocked has 1 dependents and its deletion policy is Block: DBaaSInstance default/testinstance-blocked"))
+				assertResourceDeletion(instance)()
+				Eventually(func() error {
+					return k8sClient.Delete(ctx, blockedInventory)
+				}, timeout, interval).Should(Succeed())
+			})
+		})
 	Context("After creating DBaaSInventory for RDS", func() {
 		testSecretRDS2 := corev1.Secret{
 			TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,75 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBaaSInventoryAccessReviewSpec defines the inventory checked by a DBaaSInventoryAccessReview
+type DBaaSInventoryAccessReviewSpec struct {
+	// The inventory the namespace of the review would reference, defaulting to the namespace of the review
+	InventoryRef NamespacedName `json:"inventoryRef"`
+}
+
+// DBaaSInventoryAccessReviewStatus defines the observed state of DBaaSInventoryAccessReview
+type DBaaSInventoryAccessReviewStatus struct {
+	// Whether DBaaSConnections and DBaaSInstances in the namespace of the review may reference the inventory
+	Allowed bool `json:"allowed"`
+
+	// A machine-readable reason for the answer
+	Reason string `json:"reason,omitempty"`
+
+	// A human-readable explanation of the answer
+	Message string `json:"message,omitempty"`
+
+	// The generation of the review the answer applies to
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Inventory",type=string,JSONPath=`.spec.inventoryRef.name`
+//+kubebuilder:printcolumn:name="Allowed",type=boolean,JSONPath=`.status.allowed`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DBaaSInventoryAccessReview is the Schema for the dbaasinventoryaccessreviews API. It checks whether the namespace of
+// the review may reference an inventory with the current policies, without creating a DBaaSConnection or DBaaSInstance.
+// The answer is evaluated when the review is created or its spec changes, and re-evaluated when the policies, the
+// inventory or the labels of the namespace change.
+//+operator-sdk:csv:customresourcedefinitions:displayName="DBaaSInventoryAccessReview"
+type DBaaSInventoryAccessReview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInventoryAccessReviewSpec   `json:"spec,omitempty"`
+	Status DBaaSInventoryAccessReviewStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSInventoryAccessReviewList contains a list of DBaaSInventoryAccessReview
+type DBaaSInventoryAccessReviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSInventoryAccessReview `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSInventoryAccessReview{}, &DBaaSInventoryAccessReviewList{})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBaaSInventoryMigrationSpec defines the desired state of DBaaSInventoryMigration
+type DBaaSInventoryMigrationSpec struct {
+	// The inventory the connections and instances are migrated from, in the namespace of the migration
+	SourceInventoryRef LocalObjectReference `json:"sourceInventoryRef"`
+
+	// The inventory the connections and instances are migrated to, in the namespace of the migration.
+	// It must use the same provider as the source inventory.
+	TargetInventoryRef LocalObjectReference `json:"targetInventoryRef"`
+}
+
+// DBaaSInventoryMigrationPhase defines the phases of an inventory migration
+type DBaaSInventoryMigrationPhase string
+
+// Constants for inventory migration phases
+const (
+	MigrationPhasePending    DBaaSInventoryMigrationPhase = "Pending"
+	MigrationPhaseInProgress DBaaSInventoryMigrationPhase = "InProgress"
+	MigrationPhaseCompleted  DBaaSInventoryMigrationPhase = "Completed"
+	MigrationPhaseFailed     DBaaSInventoryMigrationPhase = "Failed"
+)
+
+// DBaaSMigrationDependentPhase defines the migration phases of a dependent
+type DBaaSMigrationDependentPhase string
+
+// Constants for the migration phases of a dependent
+const (
+	MigrationDependentMigrated DBaaSMigrationDependentPhase = "Migrated"
+	MigrationDependentFailed   DBaaSMigrationDependentPhase = "Failed"
+)
+
+// DBaaSInventoryMigrationStatus defines the observed state of DBaaSInventoryMigration
+type DBaaSInventoryMigrationStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +kubebuilder:validation:Enum=Pending;InProgress;Completed;Failed
+	// The phase of the migration. A migration is Completed once every dependent is migrated,
+	// and Failed if any dependent could not be migrated.
+	Phase DBaaSInventoryMigrationPhase `json:"phase,omitempty"`
+
+	// The number of migrated dependents
+	MigratedCount int32 `json:"migratedCount,omitempty"`
+
+	// The number of dependents that could not be migrated
+	FailedCount int32 `json:"failedCount,omitempty"`
+
+	// The migration of each dependent
+	Dependents []DBaaSInventoryMigrationDependent `json:"dependents,omitempty"`
+}
+
+// DBaaSInventoryMigrationDependent defines the migration of a DBaaSConnection or DBaaSInstance
+type DBaaSInventoryMigrationDependent struct {
+	// The kind of the dependent, DBaaSConnection or DBaaSInstance
+	Kind string `json:"kind"`
+
+	// The namespace of the dependent
+	Namespace string `json:"namespace"`
+
+	// The name of the dependent
+	Name string `json:"name"`
+
+	// The ID of the instance used by the dependent
+	InstanceID string `json:"instanceID,omitempty"`
+
+	// +kubebuilder:validation:Enum=Migrated;Failed
+	// The migration phase of the dependent
+	Phase DBaaSMigrationDependentPhase `json:"phase"`
+
+	// The reason the dependent could not be migrated
+	Message string `json:"message,omitempty"`
+
+	// The time the dependent was migrated, or failed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.sourceInventoryRef.name`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetInventoryRef.name`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DBaaSInventoryMigration is the Schema for the dbaasinventorymigrations API.
+// It moves the DBaaSConnections and DBaaSInstances of an inventory to another inventory of the same provider.
+// The instances are migrated first, the connections to an instance that failed to migrate are not migrated.
+// The provider connections are recreated with the target inventory, the provider instances are updated in place
+// since recreating them would deprovision the databases.
+//+operator-sdk:csv:customresourcedefinitions:displayName="DBaaSInventoryMigration"
+type DBaaSInventoryMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInventoryMigrationSpec   `json:"spec,omitempty"`
+	Status DBaaSInventoryMigrationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSInventoryMigrationList contains a list of DBaaSInventoryMigration
+type DBaaSInventoryMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSInventoryMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSInventoryMigration{}, &DBaaSInventoryMigrationList{})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The policy lookups below are shared by the webhooks and the controllers, so that both enforce the same rules.
+
+// EffectivePolicy returns the effective policy merged from the active policies of a namespace, or nil if none exists
+func EffectivePolicy(policies []DBaaSPolicy) *DBaaSInventoryPolicy {
+	return MergePolicies(ActivePolicies(policies))
+}
+
+// ActivePolicies returns the Ready policies of a namespace, which are merged into its effective policy
+func ActivePolicies(policies []DBaaSPolicy) []DBaaSPolicy {
+	var activePolicies []DBaaSPolicy
+	for i := range policies {
+		if apimeta.IsStatusConditionTrue(policies[i].Status.Conditions, DBaaSPolicyReadyType) {
+			activePolicies = append(activePolicies, policies[i])
+		}
+	}
+	return activePolicies
+}
+
+// GetEffectivePolicy returns the effective policy of a namespace, or nil if it has no active policy
+func GetEffectivePolicy(ctx context.Context, c client.Reader, namespace string) (*DBaaSInventoryPolicy, error) {
+	policyList := &DBaaSPolicyList{}
+	if err := c.List(ctx, policyList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return EffectivePolicy(policyList.Items), nil
+}
+
+// GetClusterPolicy returns the ClusterDBaaSPolicy, or nil if it does not exist
+func GetClusterPolicy(ctx context.Context, c client.Reader) (*ClusterDBaaSPolicy, error) {
+	clusterPolicy := &ClusterDBaaSPolicy{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ClusterDBaaSPolicyName}, clusterPolicy); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return clusterPolicy, nil
+}
+
+// GetInventoryPolicy returns the policy of an inventory, see InventoryPolicy
+func GetInventoryPolicy(ctx context.Context, c client.Reader, inventory *DBaaSInventory) (*DBaaSInventoryPolicy, error) {
+	policy, err := GetEffectivePolicy(ctx, c, inventory.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusterPolicy, err := GetClusterPolicy(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return InventoryPolicy(inventory, policy, clusterPolicy), nil
+}
+
+// IsValidConnectionNamespace checks whether a namespace is allowed to use the instances of an inventory.
+// inventory takes precedence over the effective dbaaspolicy, the cluster policy sets the defaults and upper bounds of both.
+func IsValidConnectionNamespace(ctx context.Context, c client.Reader, namespace string, inventory *DBaaSInventory) (bool, error) {
+	// valid if in same namespace as inventory
+	if namespace == inventory.Namespace {
+		return true, nil
+	}
+	policy, err := GetEffectivePolicy(ctx, c, inventory.Namespace)
+	if err != nil {
+		return false, err
+	}
+	clusterPolicy, err := GetClusterPolicy(ctx, c)
+	if err != nil {
+		return false, err
+	}
+	policy = InventoryPolicy(inventory, policy, clusterPolicy)
+
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if allowed, err := clusterPolicy.AllowsNamespace(namespace, ns.Labels); err != nil || !allowed {
+		return false, err
+	}
+	return policy.AllowsConnectionNamespace(namespace, ns.Labels)
+}
@@ -17,13 +17,49 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// MaxListedAllowedNamespaces is the maximum number of namespaces listed in the allowed namespaces of a status
+const MaxListedAllowedNamespaces = 100
+
 // DBaaSPolicySpec enables admin capabilities within a namespace and sets default inventory policy.
 // Policy defaults can be overridden on a per-inventory basis.
 type DBaaSPolicySpec struct {
 	DBaaSInventoryPolicy `json:",inline"`
+
+	// Priority of the policy when merging the policies of a namespace, higher priorities are applied first.
+	// For each field, the value of the highest priority policy setting it wins and lower priority policies only
+	// fill the fields left unset, policies of the same priority are applied in name order. Each parameter
+	// constraint is taken from the highest priority policy constraining the parameter. The denied providers
+	// of all the policies are merged.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Users and groups of developers granted the DBaaSConnection and DBaaSInstance editor roles in each namespace
+	// allowed by the effective policy of the namespace, and the DBaaSInventory viewer role in the namespace of the policy.
+	// Only the namespaces labeled "dbaas.redhat.com/developer-access=true" by their administrators are bound.
+	// The operator creates the RoleBindings, and deletes them once the namespaces or subjects are no longer allowed.
+	// +optional
+	DeveloperSubjects []DBaaSPolicySubject `json:"developerSubjects,omitempty"`
+}
+
+// DBaaSPolicySubject defines a user or group of users granted access by a policy
+type DBaaSPolicySubject struct {
+	// +kubebuilder:validation:Enum=User;Group
+	// The kind of the subject, User or Group
+	Kind string `json:"kind"`
+
+	// +kubebuilder:validation:MinLength=1
+	// The name of the user or group
+	Name string `json:"name"`
 }
 
 // DBaaSInventoryPolicy sets inventory policy
@@ -41,16 +77,240 @@ type DBaaSInventoryPolicy struct {
 	// matchExpressions are ANDed. An empty label selector matches all objects. A null
 	// label selector matches no objects.
 	ConnectionNsSelector *metav1.LabelSelector `json:"connectionNsSelector,omitempty"`
+
+	// Delete the provider objects of the DBaaSConnections whose namespace is no longer allowed to reference a policy's
+	// inventories, in addition to reporting them as InvalidNamespace. The DBaaSInstances are only reported, they are
+	// never deprovisioned. Each inventory can individually override this. If not set in either the policy or inventory
+	// object, the provider objects are kept.
+	TeardownOnInvalidNamespace *bool `json:"teardownOnInvalidNamespace,omitempty"`
+
+	// Maximum number of DBaaSInstances a namespace may hold against each of a policy's inventories.
+	// Each inventory can individually override this. If not set in either the policy or inventory object, the number is not limited.
+	// +kubebuilder:validation:Minimum=0
+	MaxInstancesPerNamespace *int32 `json:"maxInstancesPerNamespace,omitempty"`
+
+	// Maximum number of DBaaSConnections a namespace may hold against each of a policy's inventories.
+	// Each inventory can individually override this. If not set in either the policy or inventory object, the number is not limited.
+	// +kubebuilder:validation:Minimum=0
+	MaxConnectionsPerNamespace *int32 `json:"maxConnectionsPerNamespace,omitempty"`
+
+	// Maximum time to live of DBaaSInstances provisioned against a policy's inventories, counted from their creation.
+	// Each inventory can individually override this. If not set in either the policy or inventory object, instances do not expire
+	// unless they set their own time to live. Instances that a new or lowered time to live would expire sooner are given
+	// a grace period of an hour, during which they report that they are expiring.
+	MaxInstanceTTL *metav1.Duration `json:"maxInstanceTTL,omitempty"`
+
+	// Names of the DBaaSProviders that inventories are allowed to use. If not set, all the providers that are not denied are allowed.
+	// Only applies to policies, inventories cannot set it.
+	AllowedProviders *[]string `json:"allowedProviders,omitempty"`
+
+	// Names of the DBaaSProviders that inventories are not allowed to use, denied providers take precedence over allowed providers.
+	// Only applies to policies, inventories cannot set it. A provider denied by any policy of a namespace is denied.
+	DeniedProviders []string `json:"deniedProviders,omitempty"`
+
+	// Cloud providers that DBaaSInstances are allowed to be provisioned on. If not set, all the cloud providers are allowed.
+	// Each inventory can individually override this.
+	AllowedCloudProviders *[]string `json:"allowedCloudProviders,omitempty"`
+
+	// Cloud regions that DBaaSInstances are allowed to be provisioned in. If not set, all the cloud regions are allowed.
+	// Each inventory can individually override this.
+	AllowedCloudRegions *[]string `json:"allowedCloudRegions,omitempty"`
+
+	// Require an approval before provisioning DBaaSInstances against a policy's inventories, see DBaaSInstanceApproval.
+	// Each inventory can individually override this.
+	RequireInstanceApproval *bool `json:"requireInstanceApproval,omitempty"`
+
+	// Constraints on the otherInstanceParams of DBaaSInstances. A constrained parameter must be set. Each inventory can
+	// individually override the constraint of a parameter.
+	InstanceParamConstraints []DBaaSInstanceParamConstraint `json:"instanceParamConstraints,omitempty"`
+}
+
+// DBaaSInstanceParamConstraint constrains the value of a parameter of the otherInstanceParams of DBaaSInstances
+type DBaaSInstanceParamConstraint struct {
+	// The name of the parameter
+	Name string `json:"name"`
+
+	// Values the parameter is allowed to take. If not set, all the values are allowed.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	// Minimum of the parameter, whose value must then be an integer
+	Minimum *int64 `json:"minimum,omitempty"`
+
+	// Maximum of the parameter, whose value must then be an integer
+	Maximum *int64 `json:"maximum,omitempty"`
+}
+
+// AllowsProvider checks whether the policy allows inventories of a provider
+func (p *DBaaSInventoryPolicy) AllowsProvider(providerName string) bool {
+	for _, denied := range p.DeniedProviders {
+		if denied == providerName {
+			return false
+		}
+	}
+	if p.AllowedProviders == nil {
+		return true
+	}
+	for _, allowed := range *p.AllowedProviders {
+		if allowed == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateInstance checks that the policy allows provisioning an instance with a spec
+func (p *DBaaSInventoryPolicy) ValidateInstance(spec *DBaaSInstanceSpec) *field.Error {
+	path := field.NewPath("spec")
+	if p.AllowedCloudProviders != nil && !containsString(*p.AllowedCloudProviders, spec.CloudProvider) {
+		return field.NotSupported(path.Child("cloudProvider"), spec.CloudProvider, *p.AllowedCloudProviders)
+	}
+	if p.AllowedCloudRegions != nil && !containsString(*p.AllowedCloudRegions, spec.CloudRegion) {
+		return field.NotSupported(path.Child("cloudRegion"), spec.CloudRegion, *p.AllowedCloudRegions)
+	}
+	for _, constraint := range p.InstanceParamConstraints {
+		paramPath := path.Child("otherInstanceParams").Key(constraint.Name)
+		value, ok := spec.OtherInstanceParams[constraint.Name]
+		if !ok {
+			// the provider default of a missing parameter is unknown, it could be outside of the constraint
+			return field.Required(paramPath, "must be set, the parameter is constrained by the policy")
+		}
+		if constraint.AllowedValues != nil && !containsString(constraint.AllowedValues, value) {
+			return field.NotSupported(paramPath, value, constraint.AllowedValues)
+		}
+		if constraint.Minimum == nil && constraint.Maximum == nil {
+			continue
+		}
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return field.Invalid(paramPath, value, "must be an integer")
+		}
+		if constraint.Minimum != nil && number < *constraint.Minimum {
+			return field.Invalid(paramPath, value, fmt.Sprintf("must be greater than or equal to %d", *constraint.Minimum))
+		}
+		if constraint.Maximum != nil && number > *constraint.Maximum {
+			return field.Invalid(paramPath, value, fmt.Sprintf("must be less than or equal to %d", *constraint.Maximum))
+		}
+	}
+	return nil
+}
+
+// AllowsConnectionNamespace checks whether the policy allows a namespace to reference the inventories of another namespace,
+// by listing it in ConnectionNamespaces, with the "*" wildcard, or by matching its labels with ConnectionNsSelector.
+// The bounds of the cluster policy are not checked, see ClusterDBaaSPolicy.AllowsNamespace.
+func (p *DBaaSInventoryPolicy) AllowsConnectionNamespace(namespace string, namespaceLabels map[string]string) (bool, error) {
+	if p == nil {
+		return false, nil
+	}
+	if p.ConnectionNamespaces != nil {
+		for _, ns := range *p.ConnectionNamespaces {
+			if ns == "*" || ns == namespace {
+				return true, nil
+			}
+		}
+	}
+	if p.ConnectionNsSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(p.ConnectionNsSelector)
+		if err != nil {
+			return false, err
+		}
+		return selector.Matches(labels.Set(namespaceLabels)), nil
+	}
+	return false, nil
+}
+
+// AllowsAllNamespaces checks whether the policy allows all namespaces with the "*" wildcard
+func (p *DBaaSInventoryPolicy) AllowsAllNamespaces() bool {
+	return p != nil && p.ConnectionNamespaces != nil && containsString(*p.ConnectionNamespaces, "*")
+}
+
+// ListAllowedNamespaces returns the names of the namespaces allowed to reference the inventories of a namespace with
+// a policy, within the bounds of the cluster policy, in alphabetical order. The namespace of the inventories is always allowed.
+func ListAllowedNamespaces(inventoryNamespace string, policy *DBaaSInventoryPolicy, clusterPolicy *ClusterDBaaSPolicy,
+	namespaces []corev1.Namespace) ([]string, error) {
+	var names []string
+	for _, ns := range namespaces {
+		if ns.Name != inventoryNamespace {
+			ok, err := policy.AllowsConnectionNamespace(ns.Name, ns.Labels)
+			if err == nil && ok {
+				ok, err = clusterPolicy.AllowsNamespace(ns.Name, ns.Labels)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// NewAllowedNamespaces reports the namespaces allowed by a policy, listed by ListAllowedNamespaces
+func NewAllowedNamespaces(names []string, policy *DBaaSInventoryPolicy) *DBaaSAllowedNamespaces {
+	allowed := &DBaaSAllowedNamespaces{
+		Count:         int32(len(names)),
+		AllNamespaces: policy.AllowsAllNamespaces(),
+	}
+	if len(names) > MaxListedAllowedNamespaces {
+		names = names[:MaxListedAllowedNamespaces]
+	}
+	allowed.Namespaces = names
+	return allowed
 }
 
 // DBaaSPolicyStatus defines the observed state of DBaaSPolicy
 type DBaaSPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The effective inventory policy of the namespace, merged from all its active policies by priority
+	EffectivePolicy *DBaaSInventoryPolicy `json:"effectivePolicy,omitempty"`
+
+	// Names of the active policies of the namespace, in the order they are merged
+	MergedPolicies []string `json:"mergedPolicies,omitempty"`
+
+	// Current usage versus limit of the policy's inventories, per consuming namespace
+	NamespaceUsage []DBaaSNamespaceUsage `json:"namespaceUsage,omitempty"`
+
+	// The namespaces allowed to reference the policy's inventories by the effective policy, before inventory overrides
+	AllowedNamespaces *DBaaSAllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// DBaaSAllowedNamespaces reports the namespaces allowed to reference the inventories of a namespace
+type DBaaSAllowedNamespaces struct {
+	// Names of the allowed namespaces in alphabetical order, limited to the first 100
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Number of allowed namespaces
+	Count int32 `json:"count"`
+
+	// Whether the "*" wildcard allows all namespaces, within the bounds of the ClusterDBaaSPolicy
+	AllNamespaces bool `json:"allNamespaces,omitempty"`
+}
+
+// DBaaSNamespaceUsage reports the DBaaSInstances and DBaaSConnections a namespace holds against a policy's inventories
+type DBaaSNamespaceUsage struct {
+	// The consuming namespace
+	Namespace string `json:"namespace"`
+
+	// Number of DBaaSInstances in the namespace referencing the policy's inventories
+	Instances int32 `json:"instances"`
+
+	// Maximum number of DBaaSInstances allowed in the namespace, not set if unlimited
+	MaxInstances *int32 `json:"maxInstances,omitempty"`
+
+	// Number of DBaaSConnections in the namespace referencing the policy's inventories
+	Connections int32 `json:"connections"`
+
+	// Maximum number of DBaaSConnections allowed in the namespace, not set if unlimited
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.conditions[0].status`
+//+kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // DBaaSPolicy enables admin capabilities within a namespace and sets default inventory policy.
@@ -73,6 +333,99 @@ type DBaaSPolicyList struct {
 	Items           []DBaaSPolicy `json:"items"`
 }
 
+// SortPolicies sorts policies in merge order: by decreasing priority, then by name
+func SortPolicies(policies []DBaaSPolicy) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Spec.Priority != policies[j].Spec.Priority {
+			return policies[i].Spec.Priority > policies[j].Spec.Priority
+		}
+		return policies[i].Name < policies[j].Name
+	})
+}
+
+// MergePolicies returns the effective inventory policy of a set of policies, or nil if there are none.
+// The policies are merged by decreasing priority, then by name:
+//   - DeniedProviders is the union of the denied providers of all the policies, so that no policy can allow back
+//     a provider denied by another
+//   - InstanceParamConstraints are merged per parameter, the highest priority policy constraining a parameter wins
+//   - for every other field, the highest priority policy setting it wins and the other policies only fill it if unset
+func MergePolicies(policies []DBaaSPolicy) *DBaaSInventoryPolicy {
+	if len(policies) == 0 {
+		return nil
+	}
+	sorted := make([]DBaaSPolicy, len(policies))
+	copy(sorted, policies)
+	SortPolicies(sorted)
+
+	effective := DBaaSInventoryPolicy{}
+	for i := range sorted {
+		policy := &sorted[i].Spec.DBaaSInventoryPolicy
+		if effective.DisableProvisions == nil {
+			effective.DisableProvisions = policy.DisableProvisions
+		}
+		if effective.ConnectionNsSelector == nil {
+			effective.ConnectionNsSelector = policy.ConnectionNsSelector
+		}
+		if effective.TeardownOnInvalidNamespace == nil {
+			effective.TeardownOnInvalidNamespace = policy.TeardownOnInvalidNamespace
+		}
+		if effective.MaxInstancesPerNamespace == nil {
+			effective.MaxInstancesPerNamespace = policy.MaxInstancesPerNamespace
+		}
+		if effective.MaxConnectionsPerNamespace == nil {
+			effective.MaxConnectionsPerNamespace = policy.MaxConnectionsPerNamespace
+		}
+		if effective.MaxInstanceTTL == nil {
+			effective.MaxInstanceTTL = policy.MaxInstanceTTL
+		}
+		if effective.AllowedProviders == nil {
+			effective.AllowedProviders = policy.AllowedProviders
+		}
+		effective.DeniedProviders = unionStrings(effective.DeniedProviders, policy.DeniedProviders)
+		if effective.AllowedCloudProviders == nil {
+			effective.AllowedCloudProviders = policy.AllowedCloudProviders
+		}
+		if effective.AllowedCloudRegions == nil {
+			effective.AllowedCloudRegions = policy.AllowedCloudRegions
+		}
+		if effective.RequireInstanceApproval == nil {
+			effective.RequireInstanceApproval = policy.RequireInstanceApproval
+		}
+		effective.InstanceParamConstraints = overrideParamConstraints(effective.InstanceParamConstraints, policy.InstanceParamConstraints)
+		if effective.ConnectionNamespaces == nil {
+			effective.ConnectionNamespaces = policy.ConnectionNamespaces
+		}
+	}
+	return effective.DeepCopy()
+}
+
+// overrideParamConstraints appends the constraints of the parameters that are not constrained yet
+func overrideParamConstraints(constraints, base []DBaaSInstanceParamConstraint) []DBaaSInstanceParamConstraint {
+	for _, constraint := range base {
+		found := false
+		for _, c := range constraints {
+			if c.Name == constraint.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
+
+// unionStrings appends the values missing from a list, keeping the order of the values
+func unionStrings(values, added []string) []string {
+	for _, value := range added {
+		if !containsString(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func init() {
 	SchemeBuilder.Register(&DBaaSPolicy{}, &DBaaSPolicyList{})
 }
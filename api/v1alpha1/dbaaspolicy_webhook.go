@@ -17,18 +17,29 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 // log is for logging in this package.
 var dbaaspolicylog = logf.Log.WithName("dbaaspolicy-resource")
+var policyWebhookAPIClient client.Client
 
 // SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if policyWebhookAPIClient == nil {
+		policyWebhookAPIClient = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -63,5 +74,58 @@ func validatePolicy(policy *DBaaSPolicy) error {
 			return err
 		}
 	}
+	if err := validateParamConstraints(policy.Spec.InstanceParamConstraints, field.NewPath("spec").Child("instanceParamConstraints")); err != nil {
+		return err
+	}
+	// Check the bounds of the cluster policy
+	clusterPolicy, err := GetClusterPolicy(context.TODO(), policyWebhookAPIClient)
+	if err != nil || clusterPolicy == nil {
+		return err
+	}
+	return validatePolicyBounds(&policy.Spec.DBaaSInventoryPolicy, clusterPolicy.Spec.Bounds, field.NewPath("spec"))
+}
+
+// validateParamConstraints checks that the parameters are constrained once, within consistent limits
+func validateParamConstraints(constraints []DBaaSInstanceParamConstraint, path *field.Path) error {
+	names := map[string]bool{}
+	for i, constraint := range constraints {
+		if len(constraint.Name) == 0 {
+			return field.Required(path.Index(i).Child("name"), "the name of the parameter is required")
+		}
+		if names[constraint.Name] {
+			return field.Duplicate(path.Index(i).Child("name"), constraint.Name)
+		}
+		names[constraint.Name] = true
+		if constraint.Minimum != nil && constraint.Maximum != nil && *constraint.Minimum > *constraint.Maximum {
+			return field.Invalid(path.Index(i).Child("minimum"), *constraint.Minimum, "must be less than or equal to the maximum")
+		}
+	}
+	return nil
+}
+
+// get the per namespace limit of an inventory. inventory takes precedence over the effective dbaaspolicy.
+// returns a nil limit if the inventory does not exist or no limit is set.
+func getNamespaceLimit(apiClient client.Client, inventoryRef NamespacedName, limitFn func(*DBaaSInventoryPolicy) *int32) (*int32, error) {
+	inventory := &DBaaSInventory{}
+	if err := apiClient.Get(context.TODO(), types.NamespacedName{Name: inventoryRef.Name, Namespace: inventoryRef.Namespace}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			// the controller reports the missing inventory
+			return nil, nil
+		}
+		return nil, err
+	}
+	policy, err := GetInventoryPolicy(context.TODO(), apiClient, inventory)
+	if err != nil {
+		return nil, err
+	}
+	return limitFn(policy), nil
+}
+
+// validateNamespaceQuota checks that a namespace holding used objects against an inventory can reference it with another one
+func validateNamespaceQuota(namespace, kind string, used int, limit *int32, inventoryRef NamespacedName) error {
+	if limit != nil && used >= int(*limit) {
+		return fmt.Errorf("namespace %s has reached the limit of %d %s for the provider account %s in namespace %s",
+			namespace, *limit, kind, inventoryRef.Name, inventoryRef.Namespace)
+	}
 	return nil
 }
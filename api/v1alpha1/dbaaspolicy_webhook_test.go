@@ -84,3 +84,73 @@ var _ = Describe("DBaaSPolicy Webhook", func() {
 			})
 		})
 })
+
+var _ = Describe("MergePolicies", func() {
+	isTrue := true
+	isFalse := false
+	maxInstances := int32(2)
+	newPolicy := func(name string, priority int32, policy DBaaSInventoryPolicy) DBaaSPolicy {
+		return DBaaSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec:       DBaaSPolicySpec{Priority: priority, DBaaSInventoryPolicy: policy},
+		}
+	}
+
+	Context("with conflicting policies", func() {
+		It("should keep the settings of the highest priority policy", func() {
+			low := newPolicy("a-low", 0, DBaaSInventoryPolicy{
+				DisableProvisions:    &isFalse,
+				ConnectionNamespaces: &[]string{"*"},
+				DeniedProviders:      []string{"provider-a"},
+				InstanceParamConstraints: []DBaaSInstanceParamConstraint{
+					{Name: "plan", AllowedValues: []string{"small", "large"}},
+				},
+			})
+			high := newPolicy("b-high", 10, DBaaSInventoryPolicy{
+				DisableProvisions:    &isTrue,
+				ConnectionNamespaces: &[]string{"app"},
+				DeniedProviders:      []string{"provider-b"},
+				InstanceParamConstraints: []DBaaSInstanceParamConstraint{
+					{Name: "plan", AllowedValues: []string{"small"}},
+				},
+			})
+
+			effective := MergePolicies([]DBaaSPolicy{low, high})
+			Expect(effective.DisableProvisions).Should(Equal(&isTrue))
+			Expect(effective.ConnectionNamespaces).Should(Equal(&[]string{"app"}))
+			Expect(effective.DeniedProviders).Should(Equal([]string{"provider-b", "provider-a"}))
+			Expect(effective.InstanceParamConstraints).Should(Equal([]DBaaSInstanceParamConstraint{
+				{Name: "plan", AllowedValues: []string{"small"}},
+			}))
+		})
+
+		It("should fill the unset settings from the lower priority policies", func() {
+			low := newPolicy("a-low", 0, DBaaSInventoryPolicy{
+				ConnectionNamespaces:     &[]string{"*"},
+				MaxInstancesPerNamespace: &maxInstances,
+			})
+			high := newPolicy("b-high", 10, DBaaSInventoryPolicy{
+				DisableProvisions: &isTrue,
+			})
+
+			effective := MergePolicies([]DBaaSPolicy{high, low})
+			Expect(effective.DisableProvisions).Should(Equal(&isTrue))
+			Expect(effective.ConnectionNamespaces).Should(Equal(&[]string{"*"}))
+			Expect(effective.MaxInstancesPerNamespace).Should(Equal(&maxInstances))
+		})
+
+		It("should merge policies with the same priority by name", func() {
+			first := newPolicy("a-first", 5, DBaaSInventoryPolicy{ConnectionNamespaces: &[]string{"first"}})
+			second := newPolicy("b-second", 5, DBaaSInventoryPolicy{ConnectionNamespaces: &[]string{"second"}})
+
+			Expect(MergePolicies([]DBaaSPolicy{second, first}).ConnectionNamespaces).Should(Equal(&[]string{"first"}))
+			Expect(MergePolicies([]DBaaSPolicy{first, second}).ConnectionNamespaces).Should(Equal(&[]string{"first"}))
+		})
+	})
+
+	Context("without policies", func() {
+		It("should return no effective policy", func() {
+			Expect(MergePolicies(nil)).Should(BeNil())
+		})
+	})
+})
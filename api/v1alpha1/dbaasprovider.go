@@ -21,15 +21,35 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// IsAuthenticationFailure checks whether the reason of a failed provider sync means the provider rejected the credentials
+func IsAuthenticationFailure(reason string) bool {
+	switch reason {
+	case ProviderAuthenticationFailed, ProviderUnauthorized, ProviderInvalidCredentials:
+		return true
+	}
+	return false
+}
+
 // Constants for DBaaS condition types, reasons, messages and type labels
 const (
 	// DBaaS condition types
 	DBaaSInventoryReadyType         string = "InventoryReady"
 	DBaaSInventoryProviderSyncType  string = "SpecSynced"
+	DBaaSInventoryCredentialsType   string = "CredentialsValid"
+	DBaaSInventorySyncedType        string = "Synced"
+	DBaaSInventoryDeletedType       string = "InventoryDeleted"
+	DBaaSInventoryMigratedType      string = "MigrationComplete"
+	DBaaSInventoryPolicyViolation   string = "PolicyViolation"
 	DBaaSConnectionReadyType        string = "ConnectionReady"
 	DBaaSConnectionProviderSyncType string = "ReadyForBinding"
 	DBaaSInstanceReadyType          string = "InstanceReady"
 	DBaaSInstanceProviderSyncType   string = "ProvisionReady"
+	DBaaSBackupReadyType            string = "BackupReady"
+	DBaaSBackupProviderSyncType     string = "BackupCompleted"
+	DBaaSRestoreReadyType           string = "RestoreReady"
+	DBaaSRestoreProviderSyncType    string = "RestoreCompleted"
+	DBaaSInstanceExpiringType       string = "Expiring"
+	DBaaSInstanceApprovalType       string = "AwaitingApproval"
 	DBaaSPolicyReadyType            string = "PolicyReady"
 	DBaaSPlatformReadyType          string = "PlatformReady"
 
@@ -41,8 +61,38 @@ const (
 	DBaaSInventoryNotFound         string = "DBaaSInventoryNotFound"
 	DBaaSInventoryNotReady         string = "DBaaSInventoryNotReady"
 	DBaaSInventoryNotProvisionable string = "DBaaSInventoryNotProvisionable"
+	DBaaSInventoryHasDependents    string = "DBaaSInventoryHasDependents"
+	DBaaSProviderNotAllowed        string = "DBaaSProviderNotAllowed"
+	DBaaSMigrationProviderMismatch string = "DBaaSMigrationProviderMismatch"
+	DBaaSMigrationInProgress       string = "DBaaSMigrationInProgress"
+	DBaaSMigrationFailed           string = "DBaaSMigrationFailed"
 	DBaaSInvalidNamespace          string = "InvalidNamespace"
+	DBaaSNamespaceAllowed          string = "NamespaceAllowed"
 	DBaaSInstanceNotAvailable      string = "DBaaSInstanceNotAvailable"
+	DBaaSInstanceClassNotFound     string = "DBaaSInstanceClassNotFound"
+	DBaaSInstanceClassInvalid      string = "DBaaSInstanceClassInvalid"
+	DBaaSInstancePolicyViolation   string = "DBaaSInstancePolicyViolation"
+	DBaaSInstanceAwaitingApproval  string = "DBaaSInstanceAwaitingApproval"
+	DBaaSInstanceRejected          string = "DBaaSInstanceRejected"
+	InstanceApprovalPending        string = "ApprovalPending"
+	InstanceApproverUnauthorized   string = "ApproverUnauthorized"
+	DBaaSBackupNotSupported        string = "DBaaSBackupNotSupported"
+	DBaaSRestoreNotSupported       string = "DBaaSRestoreNotSupported"
+	DBaaSCloneNotSupported         string = "DBaaSCloneNotSupported"
+	DBaaSCloneSourceNotAvailable   string = "DBaaSCloneSourceNotAvailable"
+	DBaaSPauseNotSupported         string = "DBaaSPauseNotSupported"
+	DBaaSInvalidPowerSchedule      string = "DBaaSInvalidPowerSchedule"
+	DBaaSInstancePaused            string = "DBaaSInstancePaused"
+	CredentialsVerified            string = "CredentialsVerified"
+	CredentialsUnverified          string = "CredentialsUnverified"
+	CredentialsSourceError         string = "CredentialsSourceError"
+	ProviderAuthenticationFailed   string = "AuthenticationFailed"
+	ProviderUnauthorized           string = "Unauthorized"
+	ProviderInvalidCredentials     string = "InvalidCredentials"
+	SyncPending                    string = "SyncPending"
+	InstanceExpirationScheduled    string = "ExpirationScheduled"
+	InstanceExpirationImminent     string = "ExpirationImminent"
+	InstanceExpired                string = "Expired"
 	ProviderReconcileInprogress    string = "ProviderReconcileInprogress"
 	ProviderReconcileError         string = "ProviderReconcileError"
 	ProviderParsingError           string = "ProviderParsingError"
@@ -53,15 +103,58 @@ const (
 	MsgProviderCRStatusSyncDone      string = "Provider Custom Resource status sync completed"
 	MsgProviderCRReconcileInProgress string = "DBaaS Provider Custom Resource reconciliation in progress"
 	MsgInventoryNotReady             string = "Inventory discovery not done"
+	MsgInventoryHasDependents        string = "Inventory deletion blocked by the connections and instances referencing it"
+	MsgInventoryDeleted              string = "The referenced inventory was deleted"
+	MsgProviderNotAllowed            string = "The provider of the inventory is not allowed by the policy of its namespace"
+	MsgMigrationProviderMismatch     string = "The source and target inventories must use the same provider"
+	MsgMigrationInProgress           string = "Migrating the dependents of the source inventory"
+	MsgMigrationCompleted            string = "All the dependents of the source inventory were migrated"
+	MsgMigrationFailed               string = "Some dependents of the source inventory could not be migrated"
+	MsgCredentialsVerified           string = "Inventory discovery succeeded with the credentials"
+	MsgCredentialsUnverified         string = "Credentials not verified by a successful inventory discovery yet"
+	MsgSyncPending                   string = "Provider has not reported the inventory discovery yet"
 	MsgInventoryNotProvisionable     string = "Inventory provisioning not allowed"
 	MsgPolicyNotFound                string = "Failed to find an active Policy"
 	MsgPolicyReady                   string = "Policy is active"
 	MsgInvalidNamespace              string = "Invalid connection namespace for the referenced inventory"
+	MsgNamespaceAllowed              string = "The namespace is allowed to reference the inventory"
 	MsgPolicyNotReady                string = "Another active Policy already exists"
+	MsgInstanceClassInvalid          string = "Instance class does not apply to the provider of the referenced inventory"
+	MsgBackupNotSupported            string = "Provider does not support backups"
+	MsgRestoreNotSupported           string = "Provider does not support restores"
+	MsgCloneNotSupported             string = "Provider does not support cloning instances"
+	MsgPauseNotSupported             string = "Provider does not support pausing instances"
+	MsgInstancePaused                string = "The referenced instance is paused"
+	MsgInstanceAwaitingApproval      string = "The inventory policy requires an approval before provisioning the instance"
+	MsgInstanceApproved              string = "The provisioning of the instance was approved"
+	MsgInstanceRejected              string = "The provisioning of the instance was rejected"
+	MsgInstanceApproverUnauthorized  string = "The approver of the instance is no longer allowed to approve it"
 
 	TypeLabelValue    = "credentials"
 	TypeLabelKey      = "db-operator/type"
 	TypeLabelKeyMongo = "atlas.mongodb.com/type"
+
+	// CredentialsResourceVersionAnnotation is set on provider inventories to the resourceVersion of the
+	// credentials Secret, so that providers resync when the credentials change
+	CredentialsResourceVersionAnnotation = "dbaas.redhat.com/credentials-resource-version"
+
+	// RefreshAnnotation requests an immediate re-discovery of an inventory when set on a DBaaSInventory.
+	// The operator removes it once the request is passed to the provider.
+	RefreshAnnotation = "dbaas.redhat.com/refresh"
+
+	// RefreshTimeAnnotation is set on provider inventories to the time of the last refresh request
+	RefreshTimeAnnotation = "dbaas.redhat.com/refresh-time"
+
+	// MigrationAnnotation is set on migrated DBaaSConnections and DBaaSInstances to the name of the
+	// DBaaSInventoryMigration that changed their inventory reference
+	MigrationAnnotation = "dbaas.redhat.com/inventory-migration"
+
+	// ApproveVerb is the verb a user must be allowed on a DBaaSInstance to approve or reject its provisioning
+	ApproveVerb = "approve"
+
+	// ProviderLabelKey and InventoryLabelKey label DBaaSDiscoveredInstances with the names of their provider and inventory
+	ProviderLabelKey  = "dbaas.redhat.com/provider"
+	InventoryLabelKey = "dbaas.redhat.com/inventory"
 )
 
 // DBaasInstancePhase instance provisioning phases
@@ -78,6 +171,43 @@ const (
 	InstancePhaseReady    DBaasInstancePhase = "Ready"
 	InstancePhaseError    DBaasInstancePhase = "Error"
 	InstancePhaseFailed   DBaasInstancePhase = "Failed"
+	InstancePhasePaused   DBaasInstancePhase = "Paused"
+)
+
+// DBaaSInstancePowerState desired power state of an instance
+// +kubebuilder:validation:Enum=Running;Paused
+type DBaaSInstancePowerState string
+
+// Constants for instance power states
+const (
+	InstancePowerStateRunning DBaaSInstancePowerState = "Running"
+	InstancePowerStatePaused  DBaaSInstancePowerState = "Paused"
+)
+
+// DBaaSBackupPhase backup phases
+type DBaaSBackupPhase string
+
+// Constants for backup phases
+const (
+	BackupPhaseUnknown    DBaaSBackupPhase = "Unknown"
+	BackupPhasePending    DBaaSBackupPhase = "Pending"
+	BackupPhaseInProgress DBaaSBackupPhase = "InProgress"
+	BackupPhaseCompleted  DBaaSBackupPhase = "Completed"
+	BackupPhaseError      DBaaSBackupPhase = "Error"
+	BackupPhaseFailed     DBaaSBackupPhase = "Failed"
+)
+
+// DBaaSRestorePhase restore phases
+type DBaaSRestorePhase string
+
+// Constants for restore phases
+const (
+	RestorePhaseUnknown    DBaaSRestorePhase = "Unknown"
+	RestorePhasePending    DBaaSRestorePhase = "Pending"
+	RestorePhaseInProgress DBaaSRestorePhase = "InProgress"
+	RestorePhaseCompleted  DBaaSRestorePhase = "Completed"
+	RestorePhaseError      DBaaSRestorePhase = "Error"
+	RestorePhaseFailed     DBaaSRestorePhase = "Failed"
 )
 
 // DBaaSProviderSpec defines the desired state of DBaaSProvider
@@ -94,12 +224,27 @@ type DBaaSProviderSpec struct {
 	// InstanceKind is the name of the instance resource (CRD) defined by the provider for provisioning
 	InstanceKind string `json:"instanceKind"`
 
+	// BackupKind is the name of the backup resource (CRD) defined by the provider, if the provider supports backups
+	BackupKind string `json:"backupKind,omitempty"`
+
+	// RestoreKind is the name of the restore resource (CRD) defined by the provider, if the provider supports restores
+	RestoreKind string `json:"restoreKind,omitempty"`
+
 	// CredentialFields indicates what information to collect from UX & how to display fields in a form
 	CredentialFields []CredentialField `json:"credentialFields"`
 
 	// AllowsFreeTrial indicates whether the provider provides free trials
 	AllowsFreeTrial bool `json:"allowsFreeTrial"`
 
+	// AllowsClone indicates whether the provider can provision instances cloned from an existing instance
+	AllowsClone bool `json:"allowsClone,omitempty"`
+
+	// AllowsPause indicates whether the provider can pause and resume instances
+	AllowsPause bool `json:"allowsPause,omitempty"`
+
+	// CredentialsModes indicates how inventories can authenticate with the provider, defaults to Secret
+	CredentialsModes []CredentialsMode `json:"credentialsModes,omitempty"`
+
 	// ExternalProvisionURL URL for provisioning instances through database provider web portal
 	ExternalProvisionURL string `json:"externalProvisionURL"`
 
@@ -110,6 +255,32 @@ type DBaaSProviderSpec struct {
 	InstanceParameterSpecs []InstanceParameterSpec `json:"instanceParameterSpecs"`
 }
 
+// CredentialsMode defines how an inventory authenticates with the provider
+// +kubebuilder:validation:Enum=Secret;WorkloadIdentity
+type CredentialsMode string
+
+// Constants for credentials modes
+const (
+	// CredentialsModeSecret uses the static credentials of a Secret
+	CredentialsModeSecret CredentialsMode = "Secret"
+	// CredentialsModeWorkloadIdentity uses the projected token of a ServiceAccount, exchanged by the provider
+	// for short-lived credentials
+	CredentialsModeWorkloadIdentity CredentialsMode = "WorkloadIdentity"
+)
+
+// SupportsCredentialsMode checks whether the provider supports a credentials mode
+func (s *DBaaSProviderSpec) SupportsCredentialsMode(mode CredentialsMode) bool {
+	if len(s.CredentialsModes) == 0 {
+		return mode == CredentialsModeSecret
+	}
+	for _, supported := range s.CredentialsModes {
+		if supported == mode {
+			return true
+		}
+	}
+	return false
+}
+
 // DatabaseProvider defines the information for a DBaaSProvider
 type DatabaseProvider struct {
 	// Indicates the name used to specify Service Binding origin parameter (e.g. 'Red Hat DBaas / MongoDB Atlas')
@@ -154,8 +325,33 @@ type DBaaSInventorySpec struct {
 	// The Secret containing the provider-specific connection credentials to use with its API
 	// endpoint. The format of the Secret is specified in the provider’s operator in its
 	// DBaaSProvider CR (CredentialFields key). The Secret must exist within the same namespace
-	// as the Inventory.
-	CredentialsRef *LocalObjectReference `json:"credentialsRef"`
+	// as the Inventory. Either CredentialsRef or WorkloadIdentity must be set.
+	CredentialsRef *LocalObjectReference `json:"credentialsRef,omitempty"`
+
+	// A ServiceAccount whose projected token is exchanged by the provider for short-lived credentials,
+	// instead of the static credentials of a Secret. The provider must support the WorkloadIdentity
+	// credentials mode.
+	WorkloadIdentity *WorkloadIdentity `json:"workloadIdentity,omitempty"`
+}
+
+// CredentialsMode returns the credentials mode of the inventory
+func (s *DBaaSInventorySpec) CredentialsMode() CredentialsMode {
+	if s.WorkloadIdentity != nil {
+		return CredentialsModeWorkloadIdentity
+	}
+	return CredentialsModeSecret
+}
+
+// WorkloadIdentity defines the ServiceAccount an inventory authenticates as with the provider
+type WorkloadIdentity struct {
+	// The name of the ServiceAccount, which must exist within the same namespace as the Inventory
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// The audience of the projected token, as expected by the token exchange of the provider
+	Audience string `json:"audience,omitempty"`
+
+	// Provider-specific parameters of the token exchange, such as the role to assume
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // LocalObjectReference contains enough information to let you locate the
@@ -171,6 +367,63 @@ type DBaaSInventoryStatus struct {
 
 	// A list of instances returned from querying the DB provider
 	Instances []Instance `json:"instances,omitempty"`
+
+	// The resourceVersion of the credentials Secret last synced to the provider inventory
+	CredentialsResourceVersion string `json:"credentialsResourceVersion,omitempty"`
+
+	// The version of the credentials read from the credentials source of the inventory
+	CredentialsSourceVersion string `json:"credentialsSourceVersion,omitempty"`
+
+	// The last time the operator read the credentials source of the inventory
+	LastCredentialsFetchTime *metav1.Time `json:"lastCredentialsFetchTime,omitempty"`
+
+	// The last time the provider reported a successful inventory discovery
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// The number of instances found by the last inventory discovery, including those hidden by the instance filter
+	ObservedInstanceCount int32 `json:"observedInstanceCount,omitempty"`
+
+	// The last time a re-discovery was requested with the refresh annotation
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+
+	// A summary of the DBaaSConnections and DBaaSInstances referencing this inventory
+	Dependents *DBaaSInventoryDependents `json:"dependents,omitempty"`
+
+	// The namespaces allowed to reference this inventory by its effective policy
+	AllowedNamespaces *DBaaSAllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// DBaaSInventoryDependents summarizes the DBaaSConnections and DBaaSInstances referencing an inventory
+type DBaaSInventoryDependents struct {
+	// The number of DBaaSConnections referencing the inventory
+	ConnectionCount int32 `json:"connectionCount"`
+
+	// The number of DBaaSInstances referencing the inventory
+	InstanceCount int32 `json:"instanceCount"`
+
+	// The number of namespaces consuming the inventory
+	NamespaceCount int32 `json:"namespaceCount"`
+
+	// The dependents by consuming namespace, capped at 50 namespaces
+	Namespaces []DBaaSInventoryNamespaceDependents `json:"namespaces,omitempty"`
+}
+
+// DBaaSInventoryNamespaceDependents summarizes the dependents of an inventory in a consuming namespace
+type DBaaSInventoryNamespaceDependents struct {
+	// The consuming namespace
+	Namespace string `json:"namespace"`
+
+	// The number of DBaaSConnections in the namespace referencing the inventory
+	ConnectionCount int32 `json:"connectionCount,omitempty"`
+
+	// The number of DBaaSInstances in the namespace referencing the inventory
+	InstanceCount int32 `json:"instanceCount,omitempty"`
+
+	// The names of the DBaaSConnections, capped at 10
+	Connections []string `json:"connections,omitempty"`
+
+	// The names of the DBaaSInstances, capped at 10
+	Instances []string `json:"instances,omitempty"`
 }
 
 // Instance defines the information of a database instance
@@ -239,8 +492,13 @@ type DBaaSProviderInventory struct {
 
 // DBaaSInstanceSpec defines the desired state of DBaaSInstance
 type DBaaSInstanceSpec struct {
-	// A reference to the relevant DBaaSInventory CR
-	InventoryRef NamespacedName `json:"inventoryRef"`
+	// A reference to the relevant DBaaSInventory CR. It can be omitted if the
+	// referenced DBaaSInstanceClass sets a default inventory.
+	InventoryRef NamespacedName `json:"inventoryRef,omitempty"`
+
+	// The name of the DBaaSInstanceClass holding the provisioning presets for this instance.
+	// Fields set on the instance take precedence over the presets of the class.
+	InstanceClassName string `json:"instanceClassName,omitempty"`
 
 	// The name of this instance in the database service
 	Name string `json:"name"`
@@ -253,6 +511,50 @@ type DBaaSInstanceSpec struct {
 
 	// Any other provider-specific parameters related to the instance provisioning
 	OtherInstanceParams map[string]string `json:"otherInstanceParams,omitempty"`
+
+	// The instance to clone. The provider receives the source as an instance ID.
+	// Only supported by providers that allow cloning.
+	CloneSource *DBaaSInstanceCloneSource `json:"cloneSource,omitempty"`
+
+	// Time to live of the instance, counted from its creation. The instance and its connections
+	// are deleted once it expires. Cannot be set together with expirationTime.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// The time at which the instance and its connections are deleted. Cannot be set together with ttl.
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// How long before the expiration the instance reports that it is expiring, through an event
+	// and the Expiring condition. Defaults to one hour.
+	ExpirationWarningPeriod *metav1.Duration `json:"expirationWarningPeriod,omitempty"`
+
+	// The desired power state of the instance. Defaults to Running.
+	// Only supported by providers that allow pausing instances.
+	PowerState DBaaSInstancePowerState `json:"powerState,omitempty"`
+
+	// Schedules switching the power state of the instance, for example to pause it at night.
+	// The most recent schedule activation takes precedence over powerState.
+	PowerSchedules []DBaaSInstancePowerSchedule `json:"powerSchedules,omitempty"`
+}
+
+// DBaaSInstancePowerSchedule switches the power state of an instance on a schedule
+type DBaaSInstancePowerSchedule struct {
+	// A cron-style schedule with five fields (minute, hour, day of month, month, day of week), evaluated in UTC
+	Schedule string `json:"schedule"`
+
+	// The power state of the instance from each activation of the schedule
+	PowerState DBaaSInstancePowerState `json:"powerState"`
+}
+
+// DBaaSInstanceCloneSource defines the source of a cloned instance
+type DBaaSInstanceCloneSource struct {
+	// A reference to the DBaaSInstance CR to clone. It must use the same inventory as the clone.
+	InstanceRef *NamespacedName `json:"instanceRef,omitempty"`
+
+	// The ID of the instance to clone, as shown in the discovered instances of the inventory
+	InstanceID string `json:"instanceID,omitempty"`
+
+	// The point in time to clone the source from. If not set, the current state of the source is cloned.
+	PointInTime *metav1.Time `json:"pointInTime,omitempty"`
 }
 
 // DBaaSInstanceStatus defines the observed state of DBaaSInstance
@@ -265,7 +567,7 @@ type DBaaSInstanceStatus struct {
 	// Any other provider-specific information related to this instance
 	InstanceInfo map[string]string `json:"instanceInfo,omitempty"`
 
-	// +kubebuilder:validation:Enum=Unknown;Pending;Creating;Updating;Deleting;Deleted;Ready;Error;Failed
+	// +kubebuilder:validation:Enum=Unknown;Pending;Creating;Updating;Deleting;Deleted;Ready;Error;Failed;Paused
 	// +kubebuilder:default=Unknown
 	// Represents the cluster provisioning phase
 	// Unknown - unknown cluster provisioning status
@@ -277,7 +579,64 @@ type DBaaSInstanceStatus struct {
 	// Ready - cluster provisioning complete
 	// Error - cluster provisioning with error
 	// Failed - cluster provisioning failed
+	// Paused - cluster is paused
+	Phase DBaasInstancePhase `json:"phase"`
+
+	// The DBaaSInstanceClass presets applied to the provider instance
+	InstanceClass *AppliedInstanceClass `json:"instanceClass,omitempty"`
+
+	// The most recent phase transitions of the instance, oldest first. The history is bounded and
+	// kept by the operator, providers do not need to set it.
+	PhaseHistory []DBaaSInstancePhaseTransition `json:"phaseHistory,omitempty"`
+
+	// The time at which the instance expires, taking the maximum time to live of the policy into account
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// The power state requested from the provider, taking the power schedules into account
+	PowerState DBaaSInstancePowerState `json:"powerState,omitempty"`
+
+	// The decision on the provisioning of the instance, when its inventory policy requires approval.
+	// It is kept by the operator, providers do not need to set it.
+	Approval *DBaaSInstanceApprovalRecord `json:"approval,omitempty"`
+}
+
+// DBaaSInstanceApprovalRecord records the decision of an approver on the provisioning of an instance
+type DBaaSInstanceApprovalRecord struct {
+	// The name of the DBaaSInstanceApproval holding the decision
+	Name string `json:"name"`
+
+	// The decision of the approver
+	Decision DBaaSInstanceApprovalDecision `json:"decision"`
+
+	// The user who made the decision
+	Approver string `json:"approver,omitempty"`
+
+	// The reason of the decision
+	Message string `json:"message,omitempty"`
+
+	// The time of the decision
+	DecisionTime metav1.Time `json:"decisionTime"`
+}
+
+// DBaaSInstancePhaseTransition records when an instance entered a phase
+type DBaaSInstancePhaseTransition struct {
+	// The phase the instance entered
 	Phase DBaasInstancePhase `json:"phase"`
+
+	// The reason of the status condition at the time of the transition
+	Reason string `json:"reason,omitempty"`
+
+	// The time the instance entered the phase
+	TransitionTime metav1.Time `json:"transitionTime"`
+}
+
+// AppliedInstanceClass identifies the DBaaSInstanceClass generation applied to an instance
+type AppliedInstanceClass struct {
+	// The name of the DBaaSInstanceClass
+	Name string `json:"name"`
+
+	// The generation of the DBaaSInstanceClass that was applied
+	Generation int64 `json:"generation"`
 }
 
 // DBaaSProviderInstance is the schema for unmarshalling provider instance object
@@ -288,6 +647,96 @@ type DBaaSProviderInstance struct {
 	Status DBaaSInstanceStatus `json:"status,omitempty"`
 }
 
+// DBaaSBackupSpec defines the desired state of DBaaSBackup
+type DBaaSBackupSpec struct {
+	// A reference to the relevant DBaaSInventory CR
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// The ID of the instance to back up, as shown in the discovered instances of the inventory
+	InstanceID string `json:"instanceID"`
+
+	// Any other provider-specific parameters related to the backup
+	OtherBackupParams map[string]string `json:"otherBackupParams,omitempty"`
+}
+
+// DBaaSBackupStatus defines the observed state of DBaaSBackup
+type DBaaSBackupStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The ID of the backup in the database service
+	BackupID string `json:"backupID,omitempty"`
+
+	// The time the backup completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Any other provider-specific information related to this backup
+	BackupInfo map[string]string `json:"backupInfo,omitempty"`
+
+	// +kubebuilder:validation:Enum=Unknown;Pending;InProgress;Completed;Error;Failed
+	// +kubebuilder:default=Unknown
+	// Represents the backup phase
+	// Unknown - unknown backup status
+	// Pending - backup not yet started
+	// InProgress - backup in progress
+	// Completed - backup complete
+	// Error - backup with error
+	// Failed - backup failed
+	Phase DBaaSBackupPhase `json:"phase"`
+}
+
+// DBaaSProviderBackup is the schema for unmarshalling provider backup object
+type DBaaSProviderBackup struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSBackupSpec   `json:"spec,omitempty"`
+	Status DBaaSBackupStatus `json:"status,omitempty"`
+}
+
+// DBaaSRestoreSpec defines the desired state of DBaaSRestore
+type DBaaSRestoreSpec struct {
+	// A reference to the relevant DBaaSInventory CR
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// The ID of the backup to restore, as shown in the status of a DBaaSBackup
+	BackupID string `json:"backupID"`
+
+	// The ID of the instance to restore the backup to, as shown in the discovered instances of the inventory
+	InstanceID string `json:"instanceID"`
+
+	// Any other provider-specific parameters related to the restore
+	OtherRestoreParams map[string]string `json:"otherRestoreParams,omitempty"`
+}
+
+// DBaaSRestoreStatus defines the observed state of DBaaSRestore
+type DBaaSRestoreStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The time the restore completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Any other provider-specific information related to this restore
+	RestoreInfo map[string]string `json:"restoreInfo,omitempty"`
+
+	// +kubebuilder:validation:Enum=Unknown;Pending;InProgress;Completed;Error;Failed
+	// +kubebuilder:default=Unknown
+	// Represents the restore phase
+	// Unknown - unknown restore status
+	// Pending - restore not yet started
+	// InProgress - restore in progress
+	// Completed - restore complete
+	// Error - restore with error
+	// Failed - restore failed
+	Phase DBaaSRestorePhase `json:"phase"`
+}
+
+// DBaaSProviderRestore is the schema for unmarshalling provider restore object
+type DBaaSProviderRestore struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSRestoreSpec   `json:"spec,omitempty"`
+	Status DBaaSRestoreStatus `json:"status,omitempty"`
+}
+
 // InstanceParameterSpec defines the information for how a parameter can be collected from UX
 // and how to display fields in a form in order to provision an instance
 type InstanceParameterSpec struct {
@@ -29,8 +29,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	//+kubebuilder:scaffold:imports
@@ -94,6 +96,9 @@ var _ = BeforeSuite(func() {
 	err = corev1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = authorizationv1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	//+kubebuilder:scaffold:scheme
 
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
@@ -112,6 +117,12 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
+	// the inventory reference index is registered by the DBaaSInventory controller in the operator
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSConnection{}, InventoryRefKey, InventoryRefIndexer(mgr.GetClient()))
+	Expect(err).NotTo(HaveOccurred())
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSInstance{}, InventoryRefKey, InventoryRefIndexer(mgr.GetClient()))
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&DBaaSConnection{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -121,6 +132,15 @@ var _ = BeforeSuite(func() {
 	err = (&DBaaSPolicy{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&ClusterDBaaSPolicy{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&DBaaSInstance{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&DBaaSInstanceApproval{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	ns2 := corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: testNamespace2,
@@ -176,6 +196,30 @@ func assertResourceCreation(object client.Object) func() {
 	}
 }
 
+// setInventoryReady sets the status an inventory gets from the controller once the provider discovered the instances
+func setInventoryReady(inventory *DBaaSInventory, instanceIDs ...string) {
+	Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inventory), inventory)).Should(Succeed())
+	inventory.Status.Instances = nil
+	for _, instanceID := range instanceIDs {
+		inventory.Status.Instances = append(inventory.Status.Instances, Instance{InstanceID: instanceID, Name: instanceID})
+	}
+	apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+		Type:   DBaaSInventoryReadyType,
+		Status: metav1.ConditionTrue,
+		Reason: Ready,
+	})
+	Expect(k8sClient.Status().Update(ctx, inventory)).Should(Succeed())
+
+	By("waiting for the webhooks to see the inventory ready")
+	Eventually(func() bool {
+		cached := &DBaaSInventory{}
+		if err := connectionWebhookAPIClient.Get(ctx, client.ObjectKeyFromObject(inventory), cached); err != nil {
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(cached.Status.Conditions, DBaaSInventoryReadyType)
+	}, timeout, interval).Should(BeTrue())
+}
+
 func assertResourceDeletion(object client.Object) func() {
 	return func() {
 		By("deleting resource")
@@ -22,11 +22,125 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedInstanceClass) DeepCopyInto(out *AppliedInstanceClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedInstanceClass.
+func (in *AppliedInstanceClass) DeepCopy() *AppliedInstanceClass {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedInstanceClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDBaaSPolicy) DeepCopyInto(out *ClusterDBaaSPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDBaaSPolicy.
+func (in *ClusterDBaaSPolicy) DeepCopy() *ClusterDBaaSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDBaaSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDBaaSPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDBaaSPolicyList) DeepCopyInto(out *ClusterDBaaSPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDBaaSPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDBaaSPolicyList.
+func (in *ClusterDBaaSPolicyList) DeepCopy() *ClusterDBaaSPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDBaaSPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDBaaSPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDBaaSPolicySpec) DeepCopyInto(out *ClusterDBaaSPolicySpec) {
+	*out = *in
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(DBaaSInventoryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bounds != nil {
+		in, out := &in.Bounds, &out.Bounds
+		*out = new(DBaaSInventoryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDBaaSPolicySpec.
+func (in *ClusterDBaaSPolicySpec) DeepCopy() *ClusterDBaaSPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDBaaSPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDBaaSPolicyStatus) DeepCopyInto(out *ClusterDBaaSPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDBaaSPolicyStatus.
+func (in *ClusterDBaaSPolicyStatus) DeepCopy() *ClusterDBaaSPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDBaaSPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialField) DeepCopyInto(out *CredentialField) {
 	*out = *in
@@ -43,7 +157,27 @@ func (in *CredentialField) DeepCopy() *CredentialField {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
+func (in *DBaaSAllowedNamespaces) DeepCopyInto(out *DBaaSAllowedNamespaces) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSAllowedNamespaces.
+func (in *DBaaSAllowedNamespaces) DeepCopy() *DBaaSAllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSAllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackup) DeepCopyInto(out *DBaaSBackup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -51,18 +185,18 @@ func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnection.
-func (in *DBaaSConnection) DeepCopy() *DBaaSConnection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackup.
+func (in *DBaaSBackup) DeepCopy() *DBaaSBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnection)
+	out := new(DBaaSBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSConnection) DeepCopyObject() runtime.Object {
+func (in *DBaaSBackup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -70,31 +204,31 @@ func (in *DBaaSConnection) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnectionList) DeepCopyInto(out *DBaaSConnectionList) {
+func (in *DBaaSBackupList) DeepCopyInto(out *DBaaSBackupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSConnection, len(*in))
+		*out = make([]DBaaSBackup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionList.
-func (in *DBaaSConnectionList) DeepCopy() *DBaaSConnectionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupList.
+func (in *DBaaSBackupList) DeepCopy() *DBaaSBackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnectionList)
+	out := new(DBaaSBackupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSConnectionList) DeepCopyObject() runtime.Object {
+func (in *DBaaSBackupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -102,60 +236,63 @@ func (in *DBaaSConnectionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnectionSpec) DeepCopyInto(out *DBaaSConnectionSpec) {
+func (in *DBaaSBackupSpec) DeepCopyInto(out *DBaaSBackupSpec) {
 	*out = *in
 	out.InventoryRef = in.InventoryRef
-	if in.InstanceRef != nil {
-		in, out := &in.InstanceRef, &out.InstanceRef
-		*out = new(NamespacedName)
-		**out = **in
+	if in.OtherBackupParams != nil {
+		in, out := &in.OtherBackupParams, &out.OtherBackupParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionSpec.
-func (in *DBaaSConnectionSpec) DeepCopy() *DBaaSConnectionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupSpec.
+func (in *DBaaSBackupSpec) DeepCopy() *DBaaSBackupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnectionSpec)
+	out := new(DBaaSBackupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnectionStatus) DeepCopyInto(out *DBaaSConnectionStatus) {
+func (in *DBaaSBackupStatus) DeepCopyInto(out *DBaaSBackupStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.CredentialsRef != nil {
-		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	if in.ConnectionInfoRef != nil {
-		in, out := &in.ConnectionInfoRef, &out.ConnectionInfoRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.BackupInfo != nil {
+		in, out := &in.BackupInfo, &out.BackupInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionStatus.
-func (in *DBaaSConnectionStatus) DeepCopy() *DBaaSConnectionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupStatus.
+func (in *DBaaSBackupStatus) DeepCopy() *DBaaSBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnectionStatus)
+	out := new(DBaaSBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstance) DeepCopyInto(out *DBaaSInstance) {
+func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -163,18 +300,18 @@ func (in *DBaaSInstance) DeepCopyInto(out *DBaaSInstance) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstance.
-func (in *DBaaSInstance) DeepCopy() *DBaaSInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnection.
+func (in *DBaaSConnection) DeepCopy() *DBaaSConnection {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInstance)
+	out := new(DBaaSConnection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInstance) DeepCopyObject() runtime.Object {
+func (in *DBaaSConnection) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -182,31 +319,31 @@ func (in *DBaaSInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstanceList) DeepCopyInto(out *DBaaSInstanceList) {
+func (in *DBaaSConnectionList) DeepCopyInto(out *DBaaSConnectionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSInstance, len(*in))
+		*out = make([]DBaaSConnection, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceList.
-func (in *DBaaSInstanceList) DeepCopy() *DBaaSInstanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionList.
+func (in *DBaaSConnectionList) DeepCopy() *DBaaSConnectionList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInstanceList)
+	out := new(DBaaSConnectionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInstanceList) DeepCopyObject() runtime.Object {
+func (in *DBaaSConnectionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -214,78 +351,108 @@ func (in *DBaaSInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstanceSpec) DeepCopyInto(out *DBaaSInstanceSpec) {
+func (in *DBaaSConnectionSpec) DeepCopyInto(out *DBaaSConnectionSpec) {
 	*out = *in
 	out.InventoryRef = in.InventoryRef
-	if in.OtherInstanceParams != nil {
-		in, out := &in.OtherInstanceParams, &out.OtherInstanceParams
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(NamespacedName)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceSpec.
-func (in *DBaaSInstanceSpec) DeepCopy() *DBaaSInstanceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionSpec.
+func (in *DBaaSConnectionSpec) DeepCopy() *DBaaSConnectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInstanceSpec)
+	out := new(DBaaSConnectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstanceStatus) DeepCopyInto(out *DBaaSInstanceStatus) {
+func (in *DBaaSConnectionStatus) DeepCopyInto(out *DBaaSConnectionStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.InstanceInfo != nil {
-		in, out := &in.InstanceInfo, &out.InstanceInfo
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConnectionInfoRef != nil {
+		in, out := &in.ConnectionInfoRef, &out.ConnectionInfoRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceStatus.
-func (in *DBaaSInstanceStatus) DeepCopy() *DBaaSInstanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionStatus.
+func (in *DBaaSConnectionStatus) DeepCopy() *DBaaSConnectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInstanceStatus)
+	out := new(DBaaSConnectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInventory) DeepCopyInto(out *DBaaSInventory) {
+func (in *DBaaSCredentialsSource) DeepCopyInto(out *DBaaSCredentialsSource) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileCredentialsSource)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPCredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSCredentialsSource.
+func (in *DBaaSCredentialsSource) DeepCopy() *DBaaSCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSDiscoveredInstance) DeepCopyInto(out *DBaaSDiscoveredInstance) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventory.
-func (in *DBaaSInventory) DeepCopy() *DBaaSInventory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSDiscoveredInstance.
+func (in *DBaaSDiscoveredInstance) DeepCopy() *DBaaSDiscoveredInstance {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInventory)
+	out := new(DBaaSDiscoveredInstance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInventory) DeepCopyObject() runtime.Object {
+func (in *DBaaSDiscoveredInstance) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -293,37 +460,858 @@ func (in *DBaaSInventory) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInventoryList) DeepCopyInto(out *DBaaSInventoryList) {
+func (in *DBaaSDiscoveredInstanceList) DeepCopyInto(out *DBaaSDiscoveredInstanceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSInventory, len(*in))
+		*out = make([]DBaaSDiscoveredInstance, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryList.
-func (in *DBaaSInventoryList) DeepCopy() *DBaaSInventoryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSDiscoveredInstanceList.
+func (in *DBaaSDiscoveredInstanceList) DeepCopy() *DBaaSDiscoveredInstanceList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInventoryList)
+	out := new(DBaaSDiscoveredInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSDiscoveredInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSDiscoveredInstanceSpec) DeepCopyInto(out *DBaaSDiscoveredInstanceSpec) {
+	*out = *in
+	out.InventoryRef = in.InventoryRef
+	out.ProviderRef = in.ProviderRef
+	in.Instance.DeepCopyInto(&out.Instance)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSDiscoveredInstanceSpec.
+func (in *DBaaSDiscoveredInstanceSpec) DeepCopy() *DBaaSDiscoveredInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSDiscoveredInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstance) DeepCopyInto(out *DBaaSInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstance.
+func (in *DBaaSInstance) DeepCopy() *DBaaSInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApproval) DeepCopyInto(out *DBaaSInstanceApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApproval.
+func (in *DBaaSInstanceApproval) DeepCopy() *DBaaSInstanceApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstanceApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalList) DeepCopyInto(out *DBaaSInstanceApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInstanceApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalList.
+func (in *DBaaSInstanceApprovalList) DeepCopy() *DBaaSInstanceApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstanceApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalRecord) DeepCopyInto(out *DBaaSInstanceApprovalRecord) {
+	*out = *in
+	in.DecisionTime.DeepCopyInto(&out.DecisionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalRecord.
+func (in *DBaaSInstanceApprovalRecord) DeepCopy() *DBaaSInstanceApprovalRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalSpec) DeepCopyInto(out *DBaaSInstanceApprovalSpec) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+	if in.ApproverGroups != nil {
+		in, out := &in.ApproverGroups, &out.ApproverGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalSpec.
+func (in *DBaaSInstanceApprovalSpec) DeepCopy() *DBaaSInstanceApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalStatus) DeepCopyInto(out *DBaaSInstanceApprovalStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalStatus.
+func (in *DBaaSInstanceApprovalStatus) DeepCopy() *DBaaSInstanceApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceClass) DeepCopyInto(out *DBaaSInstanceClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceClass.
+func (in *DBaaSInstanceClass) DeepCopy() *DBaaSInstanceClass {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstanceClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceClassList) DeepCopyInto(out *DBaaSInstanceClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInstanceClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceClassList.
+func (in *DBaaSInstanceClassList) DeepCopy() *DBaaSInstanceClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstanceClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceClassSpec) DeepCopyInto(out *DBaaSInstanceClassSpec) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+	if in.InventoryRef != nil {
+		in, out := &in.InventoryRef, &out.InventoryRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.OtherInstanceParams != nil {
+		in, out := &in.OtherInstanceParams, &out.OtherInstanceParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceClassSpec.
+func (in *DBaaSInstanceClassSpec) DeepCopy() *DBaaSInstanceClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceClassStatus) DeepCopyInto(out *DBaaSInstanceClassStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceClassStatus.
+func (in *DBaaSInstanceClassStatus) DeepCopy() *DBaaSInstanceClassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceClassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceCloneSource) DeepCopyInto(out *DBaaSInstanceCloneSource) {
+	*out = *in
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceCloneSource.
+func (in *DBaaSInstanceCloneSource) DeepCopy() *DBaaSInstanceCloneSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceCloneSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceList) DeepCopyInto(out *DBaaSInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceList.
+func (in *DBaaSInstanceList) DeepCopy() *DBaaSInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceParamConstraint) DeepCopyInto(out *DBaaSInstanceParamConstraint) {
+	*out = *in
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceParamConstraint.
+func (in *DBaaSInstanceParamConstraint) DeepCopy() *DBaaSInstanceParamConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceParamConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstancePhaseTransition) DeepCopyInto(out *DBaaSInstancePhaseTransition) {
+	*out = *in
+	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstancePhaseTransition.
+func (in *DBaaSInstancePhaseTransition) DeepCopy() *DBaaSInstancePhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstancePhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstancePowerSchedule) DeepCopyInto(out *DBaaSInstancePowerSchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstancePowerSchedule.
+func (in *DBaaSInstancePowerSchedule) DeepCopy() *DBaaSInstancePowerSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstancePowerSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceSpec) DeepCopyInto(out *DBaaSInstanceSpec) {
+	*out = *in
+	out.InventoryRef = in.InventoryRef
+	if in.OtherInstanceParams != nil {
+		in, out := &in.OtherInstanceParams, &out.OtherInstanceParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CloneSource != nil {
+		in, out := &in.CloneSource, &out.CloneSource
+		*out = new(DBaaSInstanceCloneSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationWarningPeriod != nil {
+		in, out := &in.ExpirationWarningPeriod, &out.ExpirationWarningPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PowerSchedules != nil {
+		in, out := &in.PowerSchedules, &out.PowerSchedules
+		*out = make([]DBaaSInstancePowerSchedule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceSpec.
+func (in *DBaaSInstanceSpec) DeepCopy() *DBaaSInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceStatus) DeepCopyInto(out *DBaaSInstanceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstanceInfo != nil {
+		in, out := &in.InstanceInfo, &out.InstanceInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstanceClass != nil {
+		in, out := &in.InstanceClass, &out.InstanceClass
+		*out = new(AppliedInstanceClass)
+		**out = **in
+	}
+	if in.PhaseHistory != nil {
+		in, out := &in.PhaseHistory, &out.PhaseHistory
+		*out = make([]DBaaSInstancePhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(DBaaSInstanceApprovalRecord)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceStatus.
+func (in *DBaaSInstanceStatus) DeepCopy() *DBaaSInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventory) DeepCopyInto(out *DBaaSInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventory.
+func (in *DBaaSInventory) DeepCopy() *DBaaSInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryAccessReview) DeepCopyInto(out *DBaaSInventoryAccessReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryAccessReview.
+func (in *DBaaSInventoryAccessReview) DeepCopy() *DBaaSInventoryAccessReview {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryAccessReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventoryAccessReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryAccessReviewList) DeepCopyInto(out *DBaaSInventoryAccessReviewList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInventoryAccessReview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryAccessReviewList.
+func (in *DBaaSInventoryAccessReviewList) DeepCopy() *DBaaSInventoryAccessReviewList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryAccessReviewList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventoryAccessReviewList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryAccessReviewSpec) DeepCopyInto(out *DBaaSInventoryAccessReviewSpec) {
+	*out = *in
+	out.InventoryRef = in.InventoryRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryAccessReviewSpec.
+func (in *DBaaSInventoryAccessReviewSpec) DeepCopy() *DBaaSInventoryAccessReviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryAccessReviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryAccessReviewStatus) DeepCopyInto(out *DBaaSInventoryAccessReviewStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryAccessReviewStatus.
+func (in *DBaaSInventoryAccessReviewStatus) DeepCopy() *DBaaSInventoryAccessReviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryAccessReviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryDependents) DeepCopyInto(out *DBaaSInventoryDependents) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]DBaaSInventoryNamespaceDependents, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryDependents.
+func (in *DBaaSInventoryDependents) DeepCopy() *DBaaSInventoryDependents {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryDependents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryInstanceFilter) DeepCopyInto(out *DBaaSInventoryInstanceFilter) {
+	*out = *in
+	if in.NamePatterns != nil {
+		in, out := &in.NamePatterns, &out.NamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeNamePatterns != nil {
+		in, out := &in.ExcludeNamePatterns, &out.ExcludeNamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceInfo != nil {
+		in, out := &in.InstanceInfo, &out.InstanceInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryInstanceFilter.
+func (in *DBaaSInventoryInstanceFilter) DeepCopy() *DBaaSInventoryInstanceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryInstanceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryList) DeepCopyInto(out *DBaaSInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryList.
+func (in *DBaaSInventoryList) DeepCopy() *DBaaSInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryMigration) DeepCopyInto(out *DBaaSInventoryMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryMigration.
+func (in *DBaaSInventoryMigration) DeepCopy() *DBaaSInventoryMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventoryMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryMigrationDependent) DeepCopyInto(out *DBaaSInventoryMigrationDependent) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryMigrationDependent.
+func (in *DBaaSInventoryMigrationDependent) DeepCopy() *DBaaSInventoryMigrationDependent {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryMigrationDependent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryMigrationList) DeepCopyInto(out *DBaaSInventoryMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInventoryMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryMigrationList.
+func (in *DBaaSInventoryMigrationList) DeepCopy() *DBaaSInventoryMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventoryMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryMigrationSpec) DeepCopyInto(out *DBaaSInventoryMigrationSpec) {
+	*out = *in
+	out.SourceInventoryRef = in.SourceInventoryRef
+	out.TargetInventoryRef = in.TargetInventoryRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryMigrationSpec.
+func (in *DBaaSInventoryMigrationSpec) DeepCopy() *DBaaSInventoryMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryMigrationStatus) DeepCopyInto(out *DBaaSInventoryMigrationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = make([]DBaaSInventoryMigrationDependent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryMigrationStatus.
+func (in *DBaaSInventoryMigrationStatus) DeepCopy() *DBaaSInventoryMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryNamespaceDependents) DeepCopyInto(out *DBaaSInventoryNamespaceDependents) {
+	*out = *in
+	if in.Connections != nil {
+		in, out := &in.Connections, &out.Connections
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryNamespaceDependents.
+func (in *DBaaSInventoryNamespaceDependents) DeepCopy() *DBaaSInventoryNamespaceDependents {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryNamespaceDependents)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInventoryList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSInventoryPolicy) DeepCopyInto(out *DBaaSInventoryPolicy) {
 	*out = *in
@@ -343,9 +1331,73 @@ func (in *DBaaSInventoryPolicy) DeepCopyInto(out *DBaaSInventoryPolicy) {
 	}
 	if in.ConnectionNsSelector != nil {
 		in, out := &in.ConnectionNsSelector, &out.ConnectionNsSelector
-		*out = new(metav1.LabelSelector)
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TeardownOnInvalidNamespace != nil {
+		in, out := &in.TeardownOnInvalidNamespace, &out.TeardownOnInvalidNamespace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxInstancesPerNamespace != nil {
+		in, out := &in.MaxInstancesPerNamespace, &out.MaxInstancesPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConnectionsPerNamespace != nil {
+		in, out := &in.MaxConnectionsPerNamespace, &out.MaxConnectionsPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxInstanceTTL != nil {
+		in, out := &in.MaxInstanceTTL, &out.MaxInstanceTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AllowedProviders != nil {
+		in, out := &in.AllowedProviders, &out.AllowedProviders
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.DeniedProviders != nil {
+		in, out := &in.DeniedProviders, &out.DeniedProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCloudProviders != nil {
+		in, out := &in.AllowedCloudProviders, &out.AllowedCloudProviders
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.AllowedCloudRegions != nil {
+		in, out := &in.AllowedCloudRegions, &out.AllowedCloudRegions
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.RequireInstanceApproval != nil {
+		in, out := &in.RequireInstanceApproval, &out.RequireInstanceApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InstanceParamConstraints != nil {
+		in, out := &in.InstanceParamConstraints, &out.InstanceParamConstraints
+		*out = make([]DBaaSInstanceParamConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryPolicy.
@@ -366,6 +1418,11 @@ func (in *DBaaSInventorySpec) DeepCopyInto(out *DBaaSInventorySpec) {
 		*out = new(LocalObjectReference)
 		**out = **in
 	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(WorkloadIdentity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventorySpec.
@@ -383,7 +1440,7 @@ func (in *DBaaSInventoryStatus) DeepCopyInto(out *DBaaSInventoryStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -395,6 +1452,28 @@ func (in *DBaaSInventoryStatus) DeepCopyInto(out *DBaaSInventoryStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastCredentialsFetchTime != nil {
+		in, out := &in.LastCredentialsFetchTime, &out.LastCredentialsFetchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRefreshTime != nil {
+		in, out := &in.LastRefreshTime, &out.LastRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = new(DBaaSInventoryDependents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(DBaaSAllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryStatus.
@@ -407,12 +1486,47 @@ func (in *DBaaSInventoryStatus) DeepCopy() *DBaaSInventoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSNamespaceUsage) DeepCopyInto(out *DBaaSNamespaceUsage) {
+	*out = *in
+	if in.MaxInstances != nil {
+		in, out := &in.MaxInstances, &out.MaxInstances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSNamespaceUsage.
+func (in *DBaaSNamespaceUsage) DeepCopy() *DBaaSNamespaceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSNamespaceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSOperatorInventorySpec) DeepCopyInto(out *DBaaSOperatorInventorySpec) {
 	*out = *in
 	out.ProviderRef = in.ProviderRef
 	in.DBaaSInventorySpec.DeepCopyInto(&out.DBaaSInventorySpec)
 	in.DBaaSInventoryPolicy.DeepCopyInto(&out.DBaaSInventoryPolicy)
+	if in.InstanceFilter != nil {
+		in, out := &in.InstanceFilter, &out.InstanceFilter
+		*out = new(DBaaSInventoryInstanceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(DBaaSCredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSOperatorInventorySpec.
@@ -509,7 +1623,7 @@ func (in *DBaaSPlatformStatus) DeepCopyInto(out *DBaaSPlatformStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -594,6 +1708,11 @@ func (in *DBaaSPolicyList) DeepCopyObject() runtime.Object {
 func (in *DBaaSPolicySpec) DeepCopyInto(out *DBaaSPolicySpec) {
 	*out = *in
 	in.DBaaSInventoryPolicy.DeepCopyInto(&out.DBaaSInventoryPolicy)
+	if in.DeveloperSubjects != nil {
+		in, out := &in.DeveloperSubjects, &out.DeveloperSubjects
+		*out = make([]DBaaSPolicySubject, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicySpec.
@@ -611,11 +1730,33 @@ func (in *DBaaSPolicyStatus) DeepCopyInto(out *DBaaSPolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EffectivePolicy != nil {
+		in, out := &in.EffectivePolicy, &out.EffectivePolicy
+		*out = new(DBaaSInventoryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MergedPolicies != nil {
+		in, out := &in.MergedPolicies, &out.MergedPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceUsage != nil {
+		in, out := &in.NamespaceUsage, &out.NamespaceUsage
+		*out = make([]DBaaSNamespaceUsage, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(DBaaSAllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyStatus.
@@ -628,6 +1769,21 @@ func (in *DBaaSPolicyStatus) DeepCopy() *DBaaSPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicySubject) DeepCopyInto(out *DBaaSPolicySubject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicySubject.
+func (in *DBaaSPolicySubject) DeepCopy() *DBaaSPolicySubject {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicySubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProvider) DeepCopyInto(out *DBaaSProvider) {
 	*out = *in
@@ -655,6 +1811,24 @@ func (in *DBaaSProvider) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderBackup) DeepCopyInto(out *DBaaSProviderBackup) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderBackup.
+func (in *DBaaSProviderBackup) DeepCopy() *DBaaSProviderBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProviderConnection) DeepCopyInto(out *DBaaSProviderConnection) {
 	*out = *in
@@ -741,6 +1915,24 @@ func (in *DBaaSProviderList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderRestore) DeepCopyInto(out *DBaaSProviderRestore) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderRestore.
+func (in *DBaaSProviderRestore) DeepCopy() *DBaaSProviderRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProviderSpec) DeepCopyInto(out *DBaaSProviderSpec) {
 	*out = *in
@@ -750,6 +1942,11 @@ func (in *DBaaSProviderSpec) DeepCopyInto(out *DBaaSProviderSpec) {
 		*out = make([]CredentialField, len(*in))
 		copy(*out, *in)
 	}
+	if in.CredentialsModes != nil {
+		in, out := &in.CredentialsModes, &out.CredentialsModes
+		*out = make([]CredentialsMode, len(*in))
+		copy(*out, *in)
+	}
 	if in.InstanceParameterSpecs != nil {
 		in, out := &in.InstanceParameterSpecs, &out.InstanceParameterSpecs
 		*out = make([]InstanceParameterSpec, len(*in))
@@ -782,6 +1979,121 @@ func (in *DBaaSProviderStatus) DeepCopy() *DBaaSProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestore) DeepCopyInto(out *DBaaSRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestore.
+func (in *DBaaSRestore) DeepCopy() *DBaaSRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestoreList) DeepCopyInto(out *DBaaSRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreList.
+func (in *DBaaSRestoreList) DeepCopy() *DBaaSRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestoreSpec) DeepCopyInto(out *DBaaSRestoreSpec) {
+	*out = *in
+	out.InventoryRef = in.InventoryRef
+	if in.OtherRestoreParams != nil {
+		in, out := &in.OtherRestoreParams, &out.OtherRestoreParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreSpec.
+func (in *DBaaSRestoreSpec) DeepCopy() *DBaaSRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestoreStatus) DeepCopyInto(out *DBaaSRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreInfo != nil {
+		in, out := &in.RestoreInfo, &out.RestoreInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreStatus.
+func (in *DBaaSRestoreStatus) DeepCopy() *DBaaSRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseProvider) DeepCopyInto(out *DatabaseProvider) {
 	*out = *in
@@ -798,6 +2110,41 @@ func (in *DatabaseProvider) DeepCopy() *DatabaseProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCredentialsSource) DeepCopyInto(out *FileCredentialsSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCredentialsSource.
+func (in *FileCredentialsSource) DeepCopy() *FileCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FileCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPCredentialsSource) DeepCopyInto(out *HTTPCredentialsSource) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPCredentialsSource.
+func (in *HTTPCredentialsSource) DeepCopy() *HTTPCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -885,7 +2232,7 @@ func (in *PlatformConfig) DeepCopyInto(out *PlatformConfig) {
 	*out = *in
 	if in.Envs != nil {
 		in, out := &in.Envs, &out.Envs
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -931,3 +2278,25 @@ func (in *ProviderIcon) DeepCopy() *ProviderIcon {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentity) DeepCopyInto(out *WorkloadIdentity) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentity.
+func (in *WorkloadIdentity) DeepCopy() *WorkloadIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+const (
+	// CredentialsSourceDirEnvVar is the env variable holding the directory that file credentials sources are relative to
+	CredentialsSourceDirEnvVar = "CREDENTIALS_SOURCE_DIR"
+	// DefaultCredentialsSourceDir is the default directory of file credentials sources
+	DefaultCredentialsSourceDir = "/etc/dbaas/credentials"
+	// CredentialsSourceAllowedHostsEnvVar is the env variable holding the comma separated hosts that HTTP credentials
+	// sources may read from, such as "vault.example.com,*.secrets.example.com"
+	CredentialsSourceAllowedHostsEnvVar = "CREDENTIALS_SOURCE_ALLOWED_HOSTS"
+
+	defaultCredentialsRefreshInterval = 5 * time.Minute
+	credentialsSourceTimeout          = 30 * time.Second
+	// maxCredentialsSize bounds the credentials read from a source, Secrets are limited to 1MiB
+	maxCredentialsSize = 1 << 20
+)
+
+// CredentialsSource reads the provider credentials of an inventory
+type CredentialsSource interface {
+	// Fetch returns the credentials, as the data of the Secret expected by the provider,
+	// and a version of the source that changes with the credentials
+	Fetch(ctx context.Context) (map[string][]byte, string, error)
+}
+
+// SecretCredentialsSource reads the credentials from a Secret
+type SecretCredentialsSource struct {
+	Client client.Client
+	Key    types.NamespacedName
+}
+
+// Fetch implements CredentialsSource, the version is the resourceVersion of the Secret
+func (s *SecretCredentialsSource) Fetch(ctx context.Context) (map[string][]byte, string, error) {
+	secret := &corev1.Secret{}
+	if err := s.Client.Get(ctx, s.Key, secret); err != nil {
+		return nil, "", err
+	}
+	return secret.Data, secret.ResourceVersion, nil
+}
+
+// FileCredentialsSource reads the credentials from a directory, one file per key, such as a mounted Secret volume
+type FileCredentialsSource struct {
+	Dir string
+}
+
+// Fetch implements CredentialsSource, the version is a hash of the credentials
+func (s *FileCredentialsSource) Fetch(ctx context.Context) (map[string][]byte, string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, "", err
+	}
+	data := map[string][]byte{}
+	size := 0
+	for _, entry := range entries {
+		// skip the hidden files and directories of mounted volumes, such as ..data
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		// follow the symlinks of mounted volumes
+		info, err := os.Stat(filepath.Join(s.Dir, entry.Name()))
+		if err != nil {
+			return nil, "", err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		if size += int(info.Size()); size > maxCredentialsSize {
+			return nil, "", fmt.Errorf("credentials in %s exceed %d bytes", s.Dir, maxCredentialsSize)
+		}
+		value, err := os.ReadFile(filepath.Join(s.Dir, entry.Name()))
+		if err != nil {
+			return nil, "", err
+		}
+		data[entry.Name()] = value
+	}
+	return data, credentialsVersion(data), nil
+}
+
+// HTTPCredentialsSource reads the credentials from a JSON object of string values returned by an HTTP GET,
+// authenticated with a bearer token. the URL, and the URLs it redirects to, must use one of the allowed hosts.
+type HTTPCredentialsSource struct {
+	URL          string
+	Token        string
+	AllowedHosts []string
+	Client       *http.Client
+}
+
+// Fetch implements CredentialsSource, the version is the ETag of the response if any, or a hash of the credentials
+func (s *HTTPCredentialsSource) Fetch(ctx context.Context) (map[string][]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.checkHost(req); err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	if len(s.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	httpClient := &http.Client{Timeout: credentialsSourceTimeout}
+	if s.Client != nil {
+		*httpClient = *s.Client
+	}
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("credentials service %s redirected too many times", s.URL)
+		}
+		return s.checkHost(req)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("credentials service %s returned %s", s.URL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCredentialsSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(body) > maxCredentialsSize {
+		return nil, "", fmt.Errorf("credentials from %s exceed %d bytes", s.URL, maxCredentialsSize)
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil, "", fmt.Errorf("credentials from %s are not a JSON object of strings: %w", s.URL, err)
+	}
+	data := make(map[string][]byte, len(values))
+	for key, value := range values {
+		data[key] = []byte(value)
+	}
+	if etag := resp.Header.Get("ETag"); len(etag) > 0 {
+		return data, strings.Trim(etag, `"`), nil
+	}
+	return data, credentialsVersion(data), nil
+}
+
+// checkHost checks that a request of the source uses one of the allowed hosts
+func (s *HTTPCredentialsSource) checkHost(req *http.Request) error {
+	if !v1alpha1.IsCredentialsSourceHostAllowed(req.URL.Hostname(), s.AllowedHosts) {
+		return fmt.Errorf("credentials service host %s is not allowed", req.URL.Hostname())
+	}
+	return nil
+}
+
+// credentialsVersion returns a hash of credentials, so that the version changes with them
+func credentialsVersion(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s\x00%d\x00", key, len(data[key]))
+		h.Write(data[key])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+}
+
+// getCredentialsSource returns the credentials source of an inventory, which defaults to its credentials Secret
+func (r *DBaaSInventoryReconciler) getCredentialsSource(ctx context.Context, inventory *v1alpha1.DBaaSInventory) (CredentialsSource, error) {
+	source := inventory.Spec.CredentialsSource
+	switch {
+	case source == nil:
+		if inventory.Spec.CredentialsRef == nil {
+			return nil, nil
+		}
+		return &SecretCredentialsSource{
+			Client: r.Client,
+			Key:    types.NamespacedName{Name: inventory.Spec.CredentialsRef.Name, Namespace: inventory.Namespace},
+		}, nil
+	case source.File != nil:
+		dir, err := getCredentialsSourcePath(r.CredentialsSourceDir, inventory.Namespace, source.File.Path)
+		if err != nil {
+			return nil, err
+		}
+		return &FileCredentialsSource{Dir: dir}, nil
+	case source.HTTP != nil:
+		httpSource := &HTTPCredentialsSource{URL: source.HTTP.URL, AllowedHosts: r.CredentialsSourceAllowedHosts}
+		if tokenRef := source.HTTP.TokenSecretRef; tokenRef != nil {
+			secret := &corev1.Secret{}
+			if err := r.Get(ctx, types.NamespacedName{Name: tokenRef.Name, Namespace: inventory.Namespace}, secret); err != nil {
+				return nil, err
+			}
+			token, ok := secret.Data[tokenRef.Key]
+			if !ok {
+				return nil, fmt.Errorf("key %s not found in secret %s", tokenRef.Key, tokenRef.Name)
+			}
+			httpSource.Token = strings.TrimSpace(string(token))
+		}
+		return httpSource, nil
+	}
+	return nil, fmt.Errorf("credentials source of inventory %s has no file or http source", inventory.Name)
+}
+
+// getCredentialsSourcePath returns the directory of a file credentials source, which must not escape the credentials
+// directory of the inventory namespace
+func getCredentialsSourcePath(baseDir, namespace, path string) (string, error) {
+	if len(baseDir) == 0 {
+		baseDir = DefaultCredentialsSourceDir
+	}
+	baseDir = filepath.Join(baseDir, namespace)
+	dir := filepath.Join(baseDir, path)
+	if rel, err := filepath.Rel(baseDir, dir); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("credentials path %s is outside of %s", path, baseDir)
+	}
+	return dir, nil
+}
+
+// getCredentialsRefreshInterval returns how often the credentials source of an inventory is read
+func getCredentialsRefreshInterval(source *v1alpha1.DBaaSCredentialsSource) time.Duration {
+	if source.RefreshInterval != nil && source.RefreshInterval.Duration > 0 {
+		return source.RefreshInterval.Duration
+	}
+	return defaultCredentialsRefreshInterval
+}
+
+// nextCredentialsFetch returns the delay until the credentials source of an inventory is read again, zero if it is due.
+// the source is read at most once per refresh interval, unless a refresh is requested.
+func nextCredentialsFetch(inventory *v1alpha1.DBaaSInventory, now time.Time) time.Duration {
+	lastFetchTime := inventory.Status.LastCredentialsFetchTime
+	if len(inventory.Status.CredentialsSourceVersion) == 0 || lastFetchTime == nil {
+		return 0
+	}
+	if _, refresh := inventory.GetAnnotations()[v1alpha1.RefreshAnnotation]; refresh {
+		return 0
+	}
+	if next := lastFetchTime.Add(getCredentialsRefreshInterval(inventory.Spec.CredentialsSource)).Sub(now); next > 0 {
+		return next
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+var _ = Describe("Credentials sources", func() {
+	It("should read the credentials from a mounted directory", func() {
+		dir, err := os.MkdirTemp("", "credentials")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		Expect(os.WriteFile(filepath.Join(dir, "orgId"), []byte("org"), 0600)).Should(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "privateApiKey"), []byte("key"), 0600)).Should(Succeed())
+		Expect(os.Mkdir(filepath.Join(dir, "..data"), 0700)).Should(Succeed())
+
+		source := &FileCredentialsSource{Dir: dir}
+		data, version, err := source.Fetch(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(data).Should(Equal(map[string][]byte{"orgId": []byte("org"), "privateApiKey": []byte("key")}))
+		Expect(version).ShouldNot(BeEmpty())
+
+		By("changing the version with the credentials")
+		Expect(os.WriteFile(filepath.Join(dir, "privateApiKey"), []byte("rotated"), 0600)).Should(Succeed())
+		_, rotatedVersion, err := source.Fetch(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rotatedVersion).ShouldNot(Equal(version))
+	})
+
+	It("should keep file sources in the credentials directory of the inventory namespace", func() {
+		dir, err := getCredentialsSourcePath("/etc/dbaas/credentials", "team-a", "atlas/prod")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dir).Should(Equal("/etc/dbaas/credentials/team-a/atlas/prod"))
+		_, err = getCredentialsSourcePath("/etc/dbaas/credentials", "team-a", "../team-b/atlas/prod")
+		Expect(err).Should(HaveOccurred())
+		_, err = getCredentialsSourcePath("/etc/dbaas/credentials", "team-a", "../../../var/run/secrets")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should read the credentials from a secret service", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("ETag", `"v42"`)
+			_, _ = w.Write([]byte(`{"orgId": "org", "privateApiKey": "key"}`))
+		}))
+		defer server.Close()
+
+		source := &HTTPCredentialsSource{URL: server.URL, Token: "token", AllowedHosts: []string{"127.0.0.1"}, Client: server.Client()}
+		data, version, err := source.Fetch(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(data).Should(Equal(map[string][]byte{"orgId": []byte("org"), "privateApiKey": []byte("key")}))
+		Expect(version).Should(Equal("v42"))
+
+		By("failing without the token")
+		source.Token = ""
+		_, _, err = source.Fetch(ctx)
+		Expect(err).Should(MatchError("credentials service " + server.URL + " returned 401 Unauthorized"))
+
+		By("failing for a host that is not allowed")
+		source.AllowedHosts = []string{"*.example.com"}
+		_, _, err = source.Fetch(ctx)
+		Expect(err).Should(MatchError("credentials service host 127.0.0.1 is not allowed"))
+	})
+
+	It("should not follow redirects to hosts that are not allowed", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.Redirect(w, req, "https://169.254.169.254/latest/meta-data", http.StatusFound)
+		}))
+		defer server.Close()
+
+		source := &HTTPCredentialsSource{URL: server.URL, AllowedHosts: []string{"127.0.0.1"}, Client: server.Client()}
+		_, _, err := source.Fetch(ctx)
+		Expect(err).Should(MatchError(ContainSubstring("credentials service host 169.254.169.254 is not allowed")))
+	})
+
+	It("should read the credentials source once per refresh interval", func() {
+		now := time.Now()
+		inventory := &v1alpha1.DBaaSInventory{}
+		inventory.Spec.CredentialsSource = &v1alpha1.DBaaSCredentialsSource{RefreshInterval: &metav1.Duration{Duration: time.Hour}}
+		Expect(nextCredentialsFetch(inventory, now)).Should(BeZero())
+
+		lastFetchTime := metav1.NewTime(now.Add(-time.Minute))
+		inventory.Status.CredentialsSourceVersion = "v42"
+		inventory.Status.LastCredentialsFetchTime = &lastFetchTime
+		Expect(nextCredentialsFetch(inventory, now)).Should(Equal(59 * time.Minute))
+		Expect(nextCredentialsFetch(inventory, now.Add(time.Hour))).Should(BeZero())
+
+		By("reading it again on a refresh request")
+		inventory.Annotations = map[string]string{v1alpha1.RefreshAnnotation: "true"}
+		Expect(nextCredentialsFetch(inventory, now)).Should(BeZero())
+	})
+})
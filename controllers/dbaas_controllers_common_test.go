@@ -49,6 +49,8 @@ const (
 	testInventoryKind  = "MongoDBAtlasInventory"
 	testConnectionKind = "MongoDBAtlasConnection"
 	testInstanceKind   = "MongoDBAtlasInstance"
+	testBackupKind     = "MongoDBAtlasBackup"
+	testRestoreKind    = "MongoDBAtlasRestore"
 )
 
 var mongoProvider = &v1alpha1.DBaaSProvider{
@@ -194,6 +196,20 @@ func assertProviderResourceCreated(object client.Object, providerResourceKind st
 			Expect(&providerInstance.Spec).Should(Equal(DBaaSResourceSpec))
 			Expect(len(providerInstance.GetOwnerReferences())).Should(Equal(1))
 			Expect(providerInstance.GetOwnerReferences()[0].Name).Should(Equal(object.GetName()))
+		case *v1alpha1.DBaaSBackup:
+			providerBackup := &v1alpha1.DBaaSProviderBackup{}
+			err := json.Unmarshal(bytes, providerBackup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(&providerBackup.Spec).Should(Equal(DBaaSResourceSpec))
+			Expect(len(providerBackup.GetOwnerReferences())).Should(Equal(1))
+			Expect(providerBackup.GetOwnerReferences()[0].Name).Should(Equal(object.GetName()))
+		case *v1alpha1.DBaaSRestore:
+			providerRestore := &v1alpha1.DBaaSProviderRestore{}
+			err := json.Unmarshal(bytes, providerRestore)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(&providerRestore.Spec).Should(Equal(DBaaSResourceSpec))
+			Expect(len(providerRestore.GetOwnerReferences())).Should(Equal(1))
+			Expect(providerRestore.GetOwnerReferences()[0].Name).Should(Equal(object.GetName()))
 		default:
 			_ = v.GetName() // to avoid syntax error
 			Fail("invalid test object")
@@ -224,6 +240,15 @@ func assertDBaaSResourceStatusUpdated(object client.Object, status metav1.Condit
 			case *v1alpha1.DBaaSPolicy:
 				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1alpha1.DBaaSPolicyReadyType)
 				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1alpha1.DBaaSBackup:
+				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1alpha1.DBaaSBackupReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1alpha1.DBaaSRestore:
+				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1alpha1.DBaaSRestoreReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1alpha1.DBaaSInventoryMigration:
+				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1alpha1.DBaaSInventoryMigratedType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
 			default:
 				Fail("invalid test object")
 				return false, err
@@ -376,7 +401,18 @@ func assertInventoryStatus(inv *v1alpha1.DBaaSInventory, condType string, dbaasS
 		Expect(len(dbaasConds)).Should(Equal(1))
 		Expect(dbaasConds[0].Type).Should(Equal(condType))
 		Expect(dbaasConds[0].Status).Should(Equal(dbaasStatus))
-		status.Conditions = providerConds
+		status.Conditions = nil
+		for _, cond := range providerConds {
+			// skip the conditions derived by the DBaaS operator from the provider status
+			if cond.Type != v1alpha1.DBaaSInventorySyncedType && cond.Type != v1alpha1.DBaaSInventoryCredentialsType {
+				status.Conditions = append(status.Conditions, cond)
+			}
+		}
+		Expect(status.ObservedInstanceCount).Should(Equal(int32(len(status.Instances))))
+		if inv.Spec.CredentialsRef != nil {
+			Expect(status.CredentialsResourceVersion).ShouldNot(BeEmpty())
+		}
+		status.CredentialsResourceVersion, status.ObservedInstanceCount, status.LastSyncTime = "", 0, nil
 		Expect(status).Should(Equal(providerResourceStatus))
 	}
 }
@@ -397,6 +433,10 @@ func assertInstanceStatus(conn *v1alpha1.DBaaSInstance, condType string, provide
 		status := conn.Status.DeepCopy()
 		_, providerConds := splitStatusConditions(status.Conditions, condType)
 		status.Conditions = providerConds
+		// the phase history is kept by the operator
+		Expect(status.PhaseHistory).ShouldNot(BeEmpty())
+		Expect(status.PhaseHistory[len(status.PhaseHistory)-1].Phase).Should(Equal(status.Phase))
+		status.PhaseHistory = nil
 		Expect(status).Should(Equal(providerResourceStatus))
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/go-logr/logr"
@@ -19,12 +20,17 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
@@ -85,11 +91,32 @@ func (r *DBaaSReconciler) createProviderObject(object client.Object, providerObj
 func (r *DBaaSReconciler) providerObjectMutateFn(object client.Object, providerObject *unstructured.Unstructured, spec interface{}) controllerutil.MutateFn {
 	return func() error {
 		providerObject.UnstructuredContent()["spec"] = spec
+		if inventory, ok := object.(*v1alpha1.DBaaSInventory); ok {
+			setProviderInventoryAnnotations(inventory, providerObject)
+		}
 		providerObject.SetOwnerReferences(nil)
 		return ctrl.SetControllerReference(object, providerObject, r.Scheme)
 	}
 }
 
+// setProviderInventoryAnnotations passes the credentials version and refresh requests of an inventory to the
+// provider inventory, so that providers resync when either changes
+func setProviderInventoryAnnotations(inventory *v1alpha1.DBaaSInventory, providerObject *unstructured.Unstructured) {
+	annotations := providerObject.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(inventory.Status.CredentialsResourceVersion) > 0 {
+		annotations[v1alpha1.CredentialsResourceVersionAnnotation] = inventory.Status.CredentialsResourceVersion
+	}
+	if inventory.Status.LastRefreshTime != nil {
+		annotations[v1alpha1.RefreshTimeAnnotation] = inventory.Status.LastRefreshTime.UTC().Format(time.RFC3339)
+	}
+	if len(annotations) > 0 {
+		providerObject.SetAnnotations(annotations)
+	}
+}
+
 func (r *DBaaSReconciler) parseProviderObject(unstructured *unstructured.Unstructured, object interface{}) error {
 	b, err := unstructured.MarshalJSON()
 	if err != nil {
@@ -110,69 +137,101 @@ func (r *DBaaSReconciler) policyListByNS(ctx context.Context, namespace string)
 	return policyListByNS, nil
 }
 
-// check if namespace is a valid connection namespace
-func (r *DBaaSReconciler) isValidConnectionNS(ctx context.Context, namespace string, inventory *v1alpha1.DBaaSInventory) (bool, error) {
-	// valid if in same namespace as inventory
-	if namespace == inventory.Namespace {
-		return true, nil
-	}
-	var validNamespaces []string
-	var validNsSelector *metav1.LabelSelector
-	policyList, err := r.policyListByNS(ctx, inventory.Namespace)
-	if err != nil {
-		return false, err
-	}
-	if policy := getActivePolicy(policyList); policy != nil {
-		if policy.Spec.ConnectionNamespaces != nil {
-			validNamespaces = *policy.Spec.ConnectionNamespaces
+// listAllowedNamespaces returns the names of the namespaces allowed to reference the inventories of a namespace with a policy.
+// only the candidate namespaces are read: the namespaces selected by the cluster policy bounds with the "*" wildcard,
+// otherwise the namespace of the inventories, the listed namespaces and the namespaces matching the label selector.
+func (r *DBaaSReconciler) listAllowedNamespaces(ctx context.Context, inventoryNamespace string, policy *v1alpha1.DBaaSInventoryPolicy,
+	clusterPolicy *v1alpha1.ClusterDBaaSPolicy) ([]string, error) {
+	if policy.AllowsAllNamespaces() {
+		var selector *metav1.LabelSelector
+		if clusterPolicy != nil && clusterPolicy.Spec.Bounds != nil {
+			selector = clusterPolicy.Spec.Bounds.ConnectionNsSelector
 		}
-		if policy.Spec.ConnectionNsSelector != nil {
-			validNsSelector = policy.Spec.ConnectionNsSelector
+		namespaces, err := r.listNamespacesBySelector(ctx, selector)
+		if err != nil {
+			return nil, err
 		}
-	}
-	if inventory.Spec.ConnectionNamespaces != nil {
-		validNamespaces = *inventory.Spec.ConnectionNamespaces
-	}
-	if inventory.Spec.ConnectionNsSelector != nil {
-		validNsSelector = inventory.Spec.ConnectionNsSelector
+		return v1alpha1.ListAllowedNamespaces(inventoryNamespace, policy, clusterPolicy, namespaces)
 	}
 
-	// valid if all namespaces are supported via wildcard
-	if contains(validNamespaces, "*") || contains(validNamespaces, namespace) {
-		return true, nil
+	names := []string{inventoryNamespace}
+	if policy != nil && policy.ConnectionNamespaces != nil {
+		names = append(names, *policy.ConnectionNamespaces...)
 	}
-
-	if validNsSelector != nil {
-		selector, err := metav1.LabelSelectorAsSelector(validNsSelector)
+	var namespaces []corev1.Namespace
+	found := map[string]bool{}
+	for _, name := range names {
+		if found[name] {
+			continue
+		}
+		var ns corev1.Namespace
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, &ns); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		found[name] = true
+		namespaces = append(namespaces, ns)
+	}
+	if policy != nil && policy.ConnectionNsSelector != nil {
+		selected, err := r.listNamespacesBySelector(ctx, policy.ConnectionNsSelector)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
-		var selNS corev1.NamespaceList
-		if err := r.List(ctx, &selNS, &client.ListOptions{LabelSelector: selector}); err != nil {
-			return false, err
+		for _, ns := range selected {
+			if !found[ns.Name] {
+				found[ns.Name] = true
+				namespaces = append(namespaces, ns)
+			}
 		}
-		for _, ns := range selNS.Items {
-			validNamespaces = append(validNamespaces, ns.Name)
+	}
+	return v1alpha1.ListAllowedNamespaces(inventoryNamespace, policy, clusterPolicy, namespaces)
+}
+
+// listNamespacesBySelector lists the namespaces matching a label selector, or all the namespaces without selector
+func (r *DBaaSReconciler) listNamespacesBySelector(ctx context.Context, labelSelector *metav1.LabelSelector) ([]corev1.Namespace, error) {
+	var opts []client.ListOption
+	if labelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return nil, err
 		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+	}
+	var namespaceList corev1.NamespaceList
+	if err := r.List(ctx, &namespaceList, opts...); err != nil {
+		return nil, err
 	}
-	return contains(validNamespaces, namespace), nil
+	return namespaceList.Items, nil
 }
 
-// check if provisioning is allowed against an inventory. inventory takes precedence over dbaaspolicy.
-func canProvision(inventory v1alpha1.DBaaSInventory, activePolicy *v1alpha1.DBaaSPolicy) bool {
-	if activePolicy == nil {
+// check if provisioning is allowed against an inventory. inventory takes precedence over the effective dbaaspolicy,
+// the cluster policy sets the default and upper bound of both.
+func canProvision(inventory v1alpha1.DBaaSInventory, effectivePolicy *v1alpha1.DBaaSInventoryPolicy, clusterPolicy *v1alpha1.ClusterDBaaSPolicy) bool {
+	if effectivePolicy == nil {
 		// not an active namespace
 		return false
 	}
-	if inventory.Spec.DisableProvisions != nil {
-		return !*inventory.Spec.DisableProvisions
-	}
-	if activePolicy.Spec.DisableProvisions != nil {
-		return !*activePolicy.Spec.DisableProvisions
+	policy := v1alpha1.InventoryPolicy(&inventory, effectivePolicy, clusterPolicy)
+	if policy.DisableProvisions != nil {
+		return !*policy.DisableProvisions
 	}
 	return true
 }
 
+// getInstanceSpec returns the spec used to provision an instance, with the presets of its DBaaSInstanceClass applied
+func (r *DBaaSReconciler) getInstanceSpec(ctx context.Context, instance *v1alpha1.DBaaSInstance) (*v1alpha1.DBaaSInstanceSpec, *v1alpha1.DBaaSInstanceClass, error) {
+	if len(instance.Spec.InstanceClassName) == 0 {
+		return instance.Spec.DeepCopy(), nil, nil
+	}
+	instanceClass := &v1alpha1.DBaaSInstanceClass{}
+	if err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.InstanceClassName}, instanceClass); err != nil {
+		return nil, nil, err
+	}
+	return instanceClass.MergeInstanceSpec(&instance.Spec), instanceClass, nil
+}
+
 func (r *DBaaSReconciler) reconcileProviderResource(ctx context.Context, providerName string, DBaaSObject client.Object,
 	providerObjectKindFn func(*v1alpha1.DBaaSProvider) string, DBaaSObjectSpecFn func() interface{},
 	providerObjectFn func() interface{}, DBaaSObjectSyncStatusFn func(interface{}) metav1.Condition,
@@ -249,6 +308,63 @@ func (r *DBaaSReconciler) reconcileProviderResource(ctx context.Context, provide
 	return
 }
 
+// reconcileProviderOperation reconciles a DBaaSBackup or DBaaSRestore with the provider of its inventory, once the inventory
+// is checked. operationKindFn returns the kind of the provider object, empty if the provider does not support the operation.
+func (r *DBaaSReconciler) reconcileProviderOperation(ctx context.Context, inventoryRef v1alpha1.NamespacedName, DBaaSObject client.Object,
+	statusErrorFn func(string, string), operationKindFn func(*v1alpha1.DBaaSProvider) string, notSupportedReason, notSupportedMessage string,
+	DBaaSObjectSpecFn func() interface{}, providerObjectFn func() interface{}, DBaaSObjectSyncStatusFn func(interface{}) metav1.Condition,
+	DBaaSObjectConditionsFn func() *[]metav1.Condition, DBaaSObjectReadyType string, logger logr.Logger) (ctrl.Result, error) {
+	inventory, validNS, provision, err := r.checkInventory(ctx, inventoryRef, DBaaSObject, statusErrorFn, logger)
+	if err != nil || !validNS || !provision {
+		return ctrl.Result{}, err
+	}
+
+	if provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name); err == nil && len(operationKindFn(provider)) == 0 {
+		logger.Info("DBaaS Provider does not support the operation", "DBaaS Provider", provider.Name, "DBaaS Object", DBaaSObject)
+		statusErrorFn(notSupportedReason, notSupportedMessage)
+		if err := r.Client.Status().Update(ctx, DBaaSObject); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Object modified", "DBaaS Object", DBaaSObject)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error updating the DBaaS Object status", "DBaaS Object", DBaaSObject)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileProviderResource(ctx,
+		inventory.Spec.ProviderRef.Name,
+		DBaaSObject,
+		operationKindFn,
+		DBaaSObjectSpecFn,
+		providerObjectFn,
+		DBaaSObjectSyncStatusFn,
+		DBaaSObjectConditionsFn,
+		DBaaSObjectReadyType,
+		logger,
+	)
+}
+
+// providerSyncCondition returns the ready condition of a DBaaSBackup or DBaaSRestore from the sync condition of its provider object
+func providerSyncCondition(providerConditions []metav1.Condition, providerSyncType, readyType string) metav1.Condition {
+	providerSync := apimeta.FindStatusCondition(providerConditions, providerSyncType)
+	if providerSync != nil && providerSync.Status == metav1.ConditionTrue {
+		return metav1.Condition{
+			Type:    readyType,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha1.Ready,
+			Message: v1alpha1.MsgProviderCRStatusSyncDone,
+		}
+	}
+	return metav1.Condition{
+		Type:    readyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ProviderReconcileInprogress,
+		Message: v1alpha1.MsgProviderCRReconcileInProgress,
+	}
+}
+
 func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1alpha1.NamespacedName, DBaaSObject client.Object,
 	statusErrorFn func(string, string), logger logr.Logger) (inventory *v1alpha1.DBaaSInventory, validNS, provision bool, err error) {
 	inventory = &v1alpha1.DBaaSInventory{}
@@ -273,9 +389,14 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1alp
 	if err != nil {
 		return
 	}
-	provision = canProvision(*inventory, getActivePolicy(policyList))
+	clusterPolicy, err := v1alpha1.GetClusterPolicy(ctx, r.Client)
+	if err != nil {
+		return
+	}
+	provision = canProvision(*inventory, v1alpha1.EffectivePolicy(policyList.Items), clusterPolicy)
+	policy := v1alpha1.InventoryPolicy(inventory, v1alpha1.EffectivePolicy(policyList.Items), clusterPolicy)
 
-	validNS, err = r.isValidConnectionNS(ctx, DBaaSObject.GetNamespace(), inventory)
+	validNS, err = v1alpha1.IsValidConnectionNamespace(ctx, r.Client, DBaaSObject.GetNamespace(), inventory)
 	if err != nil {
 		return
 	}
@@ -286,16 +407,31 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1alp
 			err = fmt.Errorf("inventory %v is not ready", inventoryRef)
 			logger.Error(err, "Inventory is not ready", "Inventory", inventory.Name, "Namespace", inventory.Namespace)
 			statusErrorFn(v1alpha1.DBaaSInventoryNotReady, v1alpha1.MsgInventoryNotReady)
-		} else if !provision &&
-			reflect.TypeOf(DBaaSObject) == reflect.TypeOf(&v1alpha1.DBaaSInstance{}) {
+		} else if !provision && isProvisioningObject(DBaaSObject) {
 			err = fmt.Errorf("inventory %v provisioning is disabled", inventoryRef)
 			logger.Error(err, "Inventory provisioning is disabled", "Inventory", inventory.Name, "Namespace", inventory.Namespace)
 			statusErrorFn(v1alpha1.DBaaSInventoryNotProvisionable, v1alpha1.MsgInventoryNotProvisionable)
+		} else if instance, ok := DBaaSObject.(*v1alpha1.DBaaSInstance); ok && !isInstanceProvisioned(instance) {
+			// the policy is only enforced before provisioning, a policy change does not affect the provisioned instances
+			var violation *field.Error
+			if violation, err = r.checkInstancePolicy(ctx, instance, policy); err != nil || violation == nil {
+				return
+			}
+			provision = false
+			logger.Info("Instance is not allowed by the inventory policy", "Inventory", inventory.Name, "Namespace", inventory.Namespace, "Violation", violation.Error())
+			statusErrorFn(v1alpha1.DBaaSInstancePolicyViolation, violation.Error())
 		} else {
 			return
 		}
 	} else {
 		statusErrorFn(v1alpha1.DBaaSInvalidNamespace, v1alpha1.MsgInvalidNamespace)
+		// instances are never deprovisioned on a namespace change, only the connections are torn down
+		if connection, ok := DBaaSObject.(*v1alpha1.DBaaSConnection); ok && policy.TeardownOnInvalidNamespace != nil && *policy.TeardownOnInvalidNamespace {
+			if err = r.teardownProviderConnection(ctx, connection, inventory.Spec.ProviderRef.Name); err != nil {
+				logger.Error(err, "Error deleting the provider connection of the DBaaS Connection", "DBaaS Connection", connection)
+				return
+			}
+		}
 	}
 
 	if errCond := r.Client.Status().Update(ctx, DBaaSObject); errCond != nil {
@@ -309,14 +445,141 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1alp
 	return
 }
 
-func (r *DBaaSReconciler) checkCredsRefLabel(ctx context.Context, inventory v1alpha1.DBaaSInventory) error {
+// watchNamespacePolicies watches the policies, inventories and namespace labels deciding whether the namespace of a
+// DBaaSConnection, DBaaSInstance or DBaaSInventoryAccessReview may reference its inventory, so that the dependents are
+// re-evaluated when they change.
+// newList returns an empty list of the dependents.
+func (r *DBaaSReconciler) watchNamespacePolicies(b *builder.Builder, newList func() client.ObjectList) *builder.Builder {
+	return b.
+		// the policies change the dependents with their spec, and when they become Ready and are enforced
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			var inventoryList v1alpha1.DBaaSInventoryList
+			if err := r.List(context.Background(), &inventoryList, client.InNamespace(o.GetNamespace())); err != nil {
+				ctrl.Log.WithName("DBaaSReconciler").Error(err, "unable to list inventories", "Namespace", o.GetNamespace())
+				return nil
+			}
+			var requests []reconcile.Request
+			for i := range inventoryList.Items {
+				requests = append(requests, r.inventoryDependentRequests(&inventoryList.Items[i], newList)...)
+			}
+			return requests
+		}), builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, policyReadyChangedPredicate))).
+		Watches(&source.Kind{Type: &v1alpha1.ClusterDBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return r.dependentRequests(newList())
+		}), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSInventory{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return r.inventoryDependentRequests(o.(*v1alpha1.DBaaSInventory), newList)
+		}), builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return !reflect.DeepEqual(e.ObjectOld.(*v1alpha1.DBaaSInventory).Spec.DBaaSInventoryPolicy,
+					e.ObjectNew.(*v1alpha1.DBaaSInventory).Spec.DBaaSInventoryPolicy)
+			},
+		})).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return r.dependentRequests(newList(), client.InNamespace(o.GetName()))
+		}), builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(event.CreateEvent) bool { return false },
+			DeleteFunc: func(event.DeleteEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+			},
+		}))
+}
+
+// policyReadyChangedPredicate filters the updates of the DBaaSPolicies that become Ready or stop being Ready, which
+// changes the effective policy of their namespace
+var policyReadyChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return apimeta.IsStatusConditionTrue(e.ObjectOld.(*v1alpha1.DBaaSPolicy).Status.Conditions, v1alpha1.DBaaSPolicyReadyType) !=
+			apimeta.IsStatusConditionTrue(e.ObjectNew.(*v1alpha1.DBaaSPolicy).Status.Conditions, v1alpha1.DBaaSPolicyReadyType)
+	},
+}
+
+// watchNamespaces enqueues the requests mapped from the namespaces created, deleted or relabeled
+func watchNamespaces(b *builder.Builder, mapFn handler.MapFunc) *builder.Builder {
+	return b.Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(mapFn),
+		builder.WithPredicates(predicate.LabelChangedPredicate{}))
+}
+
+// inventoryDependentRequests returns the reconcile requests of the DBaaSConnections or DBaaSInstances referencing an inventory
+func (r *DBaaSReconciler) inventoryDependentRequests(inventory *v1alpha1.DBaaSInventory, newList func() client.ObjectList) []reconcile.Request {
+	return r.dependentRequests(newList(), client.MatchingFields{
+		v1alpha1.InventoryRefKey: v1alpha1.InventoryRefIndexValue(v1alpha1.NamespacedName{Name: inventory.Name, Namespace: inventory.Namespace}),
+	})
+}
+
+// dependentRequests returns the reconcile requests of the DBaaSConnections or DBaaSInstances matching the list options
+func (r *DBaaSReconciler) dependentRequests(list client.ObjectList, opts ...client.ListOption) []reconcile.Request {
+	logger := ctrl.Log.WithName("DBaaSReconciler")
+	if err := r.List(context.Background(), list, opts...); err != nil {
+		logger.Error(err, "unable to list the dependents of the inventories")
+		return nil
+	}
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		logger.Error(err, "unable to extract the dependents of the inventories")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+		}
+	}
+	return requests
+}
+
+// listInventoryDependents lists the DBaaSConnections and DBaaSInstances referencing an inventory, using the inventoryRef indexes
+func (r *DBaaSReconciler) listInventoryDependents(ctx context.Context, inventory *v1alpha1.DBaaSInventory) (*v1alpha1.DBaaSConnectionList, *v1alpha1.DBaaSInstanceList, error) {
+	inventoryRef := client.MatchingFields{v1alpha1.InventoryRefKey: v1alpha1.InventoryRefIndexValue(v1alpha1.NamespacedName{Name: inventory.Name, Namespace: inventory.Namespace})}
+	connectionList := &v1alpha1.DBaaSConnectionList{}
+	if err := r.List(ctx, connectionList, inventoryRef); err != nil {
+		return nil, nil, err
+	}
+	instanceList := &v1alpha1.DBaaSInstanceList{}
+	if err := r.List(ctx, instanceList, inventoryRef); err != nil {
+		return nil, nil, err
+	}
+	return connectionList, instanceList, nil
+}
+
+// teardownProviderConnection deletes the provider connection of a DBaaSConnection whose namespace is no longer allowed
+// to reference its inventory
+func (r *DBaaSReconciler) teardownProviderConnection(ctx context.Context, connection *v1alpha1.DBaaSConnection, providerName string) error {
+	provider, err := r.getDBaaSProvider(ctx, providerName)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if err := r.Client.Delete(ctx, r.createProviderObject(connection, provider.Spec.ConnectionKind)); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ctrl.LoggerFrom(ctx).Info("Provider connection deleted, the namespace is no longer allowed to reference the inventory", "DBaaS Connection", client.ObjectKeyFromObject(connection))
+	return nil
+}
+
+// isInstanceProvisioned checks whether the provider has started provisioning an instance
+func isInstanceProvisioned(instance *v1alpha1.DBaaSInstance) bool {
+	return apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceProviderSyncType) != nil
+}
+
+// checkInstancePolicy checks the cloud provider, region and parameters of an instance against the policy of its inventory
+func (r *DBaaSReconciler) checkInstancePolicy(ctx context.Context, instance *v1alpha1.DBaaSInstance, policy *v1alpha1.DBaaSInventoryPolicy) (*field.Error, error) {
+	spec, _, err := r.getInstanceSpec(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	return policy.ValidateInstance(spec), nil
+}
+
+// checkCredsRefLabel labels the credentials Secret of an inventory so that it is watched, and returns its resourceVersion
+func (r *DBaaSReconciler) checkCredsRefLabel(ctx context.Context, inventory v1alpha1.DBaaSInventory) (string, error) {
 	if inventory.Spec.CredentialsRef != nil && len(inventory.Spec.CredentialsRef.Name) != 0 {
 		secret := corev1.Secret{}
 		if err := r.Get(ctx, types.NamespacedName{
 			Name:      inventory.Spec.CredentialsRef.Name,
 			Namespace: inventory.Namespace,
 		}, &secret); err != nil {
-			return err
+			return "", err
 		}
 
 		secretPatch := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}}
@@ -331,14 +594,25 @@ func (r *DBaaSReconciler) checkCredsRefLabel(ctx context.Context, inventory v1al
 		if len(secretPatch.Labels) > 0 {
 			patchBytes, err := json.Marshal(secretPatch)
 			if err != nil {
-				return err
+				return "", err
 			}
 			if err := r.Patch(ctx, &secret, client.RawPatch(types.StrategicMergePatchType, patchBytes)); err != nil {
-				return err
+				return "", err
 			}
 		}
+		return secret.ResourceVersion, nil
+	}
+	return "", nil
+}
+
+// checks if an object is subject to the provisioning policy of its inventory
+func isProvisioningObject(DBaaSObject client.Object) bool {
+	switch DBaaSObject.(type) {
+	case *v1alpha1.DBaaSInstance, *v1alpha1.DBaaSBackup, *v1alpha1.DBaaSRestore:
+		return true
+	default:
+		return false
 	}
-	return nil
 }
 
 // checks if one object is set as owner/controller of another
@@ -365,13 +639,3 @@ func GetInstallNamespace() (string, error) {
 	}
 	return ns, nil
 }
-
-// checks if a string is present in a slice
-func contains(s []string, str string) bool {
-	for _, v := range s {
-		if v == str {
-			return true
-		}
-	}
-	return false
-}
@@ -27,7 +27,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -210,75 +209,82 @@ var _ = Describe("list policies by inventory namespace", func() {
 	}
 	BeforeEach(assertResourceCreationIfNotExists(ns))
 
+	isTrue := true
+	isFalse := false
 	policy1 := getDefaultPolicy(ns.Name)
 	policy1.Name = "test-policy-1"
 	policy2 := getDefaultPolicy(ns.Name)
 	policy2.Name = "test-policy-2"
-	isTrue := true
+	policy2.Spec.Priority = 10
 	policy2.Spec.DisableProvisions = &isTrue
+	policy2.Spec.ConnectionNamespaces = &[]string{"test-namespace-app"}
 	policy3 := getDefaultPolicy(ns.Name)
 	policy3.Name = "test-policy-3"
+	policy3.Spec.DisableProvisions = &isFalse
 	BeforeEach(assertResourceCreationIfNotExists(&policy1))
 	BeforeEach(assertDBaaSResourceStatusUpdated(&policy1, metav1.ConditionTrue, v1alpha1.Ready))
 	BeforeEach(assertResourceCreationIfNotExists(&policy2))
-	BeforeEach(assertDBaaSResourceStatusUpdated(&policy2, metav1.ConditionFalse, v1alpha1.DBaaSPolicyNotReady))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&policy2, metav1.ConditionTrue, v1alpha1.Ready))
 	BeforeEach(assertResourceCreationIfNotExists(&policy3))
-	BeforeEach(assertDBaaSResourceStatusUpdated(&policy3, metav1.ConditionFalse, v1alpha1.DBaaSPolicyNotReady))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&policy3, metav1.ConditionTrue, v1alpha1.Ready))
 
 	Context("after creating DBaaSPolicies", func() {
-		It("should return all the created policies", func() {
+		It("should merge all the created policies by priority", func() {
 			policyList, err := dRec.policyListByNS(ctx, ns.Name)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(policyList.Items).Should(HaveLen(3))
-
 			for i := range policyList.Items {
-				if policyList.Items[i].Name == policy1.Name {
-					Expect(apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1alpha1.DBaaSPolicyReadyType)).Should(BeTrue())
-				} else {
-					Expect(apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1alpha1.DBaaSPolicyReadyType)).Should(BeFalse())
-				}
+				Expect(apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1alpha1.DBaaSPolicyReadyType)).Should(BeTrue())
 			}
 
-			activePolicy := getActivePolicy(policyList)
-			Expect(activePolicy).Should(Not(BeNil()))
-			Expect(activePolicy.Name).Should(Equal(policy1.Name))
+			effectivePolicy := v1alpha1.EffectivePolicy(policyList.Items)
+			Expect(effectivePolicy).Should(Not(BeNil()))
+			Expect(effectivePolicy.DisableProvisions).Should(Equal(&isTrue))
+			Expect(effectivePolicy.ConnectionNamespaces).Should(Equal(&[]string{"test-namespace-app"}))
 
-			rqList := corev1.ResourceQuotaList{}
-			Expect(dRec.List(ctx, &rqList, &client.ListOptions{Namespace: ns.Name})).Should(Succeed())
-			Expect(rqList.Items).Should(HaveLen(1))
-			Expect(rqList.Items[0].Name).Should(Equal("dbaas-" + policy1.Name))
-			Expect(rqList.Items[0].Spec.Hard).Should(Equal(corev1.ResourceList{
-				corev1.ResourceName("count/dbaaspolicies." + v1alpha1.GroupVersion.Group): resource.MustParse("1"),
-			}))
-			Expect(isOwner(&policy1, &rqList.Items[0], dRec.Scheme)).Should(BeTrue())
+			By("exposing the effective policy in the policies status")
+			Eventually(func() (*v1alpha1.DBaaSPolicyStatus, error) {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy1), &policy1)
+				return &policy1.Status, err
+			}, timeout).Should(And(
+				HaveField("EffectivePolicy", Equal(effectivePolicy)),
+				HaveField("MergedPolicies", Equal([]string{policy2.Name, policy1.Name, policy3.Name})),
+			))
+
+			By("bounding the number of policies in the namespace with a ResourceQuota per policy")
+			for _, policy := range []v1alpha1.DBaaSPolicy{policy1, policy2, policy3} {
+				rq := corev1.ResourceQuota{}
+				Eventually(func() error {
+					return dRec.Get(ctx, client.ObjectKey{Namespace: ns.Name, Name: "dbaas-" + policy.Name}, &rq)
+				}, timeout).Should(Succeed())
+				quota := rq.Spec.Hard[corev1.ResourceName("count/dbaaspolicies."+v1alpha1.GroupVersion.Group)]
+				Expect(quota.Value()).Should(Equal(int64(maxPoliciesPerNamespace)))
+			}
 
 			inventory := v1alpha1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name}}
-			Expect(canProvision(inventory, activePolicy)).Should(BeTrue())
-			activePolicy.Spec.DisableProvisions = &isTrue
-			Expect(canProvision(inventory, activePolicy)).Should(BeFalse())
+			Expect(canProvision(inventory, effectivePolicy, nil)).Should(BeFalse())
 
 			// override policy setting
-			isFalse := false
 			inventory.Spec.DisableProvisions = &isFalse
-			Expect(canProvision(inventory, activePolicy)).Should(BeTrue())
+			Expect(canProvision(inventory, effectivePolicy, nil)).Should(BeTrue())
 
 			// check nil policy
-			Expect(canProvision(inventory, nil)).Should(BeFalse())
+			Expect(canProvision(inventory, nil, nil)).Should(BeFalse())
+
+			// check the cluster policy bounds
+			clusterPolicy := &v1alpha1.ClusterDBaaSPolicy{
+				Spec: v1alpha1.ClusterDBaaSPolicySpec{
+					Bounds: &v1alpha1.DBaaSInventoryPolicy{DisableProvisions: &isTrue},
+				},
+			}
+			Expect(canProvision(inventory, effectivePolicy, clusterPolicy)).Should(BeFalse())
 		})
 
-		It("should, upon deletion, make another policy active", func() {
-			Expect(dRec.Delete(ctx, &policy1)).Should(Succeed())
-			Expect(dRec.Delete(ctx, &policy3)).Should(Succeed())
+		It("should, upon deletion, merge the remaining policies", func() {
+			Expect(dRec.Delete(ctx, &policy2)).Should(Succeed())
 			By("checking the resources deleted")
 			Eventually(func() bool {
-				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy1), &policy1)
-				if err != nil && errors.IsNotFound(err) {
-					return true
-				}
-				return false
-			}, timeout).Should(BeTrue())
-			Eventually(func() bool {
-				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy3), &policy3)
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy2), &policy2)
 				if err != nil && errors.IsNotFound(err) {
 					return true
 				}
@@ -286,24 +292,21 @@ var _ = Describe("list policies by inventory namespace", func() {
 			}, timeout).Should(BeTrue())
 
 			By("checking the DBaaS resource status")
-			Eventually(func() (bool, error) {
-				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy2), &policy2)
-				if err != nil {
-					return false, err
-				}
-				return apimeta.IsStatusConditionTrue(policy2.Status.Conditions, v1alpha1.DBaaSPolicyReadyType), nil
-			}, timeout).Should(BeTrue())
+			Eventually(func() ([]string, error) {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy3), &policy3)
+				return policy3.Status.MergedPolicies, err
+			}, timeout).Should(Equal([]string{policy1.Name, policy3.Name}))
 
 			policyList, err := dRec.policyListByNS(ctx, ns.Name)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(policyList.Items).Should(HaveLen(1))
+			Expect(policyList.Items).Should(HaveLen(2))
 
-			activePolicy := getActivePolicy(policyList)
-			Expect(activePolicy).Should(Not(BeNil()))
-			Expect(activePolicy.Name).Should(Equal(policy2.Name))
+			effectivePolicy := v1alpha1.EffectivePolicy(policyList.Items)
+			Expect(effectivePolicy).Should(Not(BeNil()))
+			Expect(effectivePolicy.DisableProvisions).Should(Equal(&isFalse))
 
 			inventory := v1alpha1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name}}
-			Expect(canProvision(inventory, activePolicy)).Should(BeFalse())
+			Expect(canProvision(inventory, effectivePolicy, nil)).Should(BeTrue())
 		})
 	})
 })
@@ -348,6 +351,9 @@ var _ = Describe("Check inventory", func() {
 						Name: testSecret2.Name,
 					},
 				},
+				DBaaSInventoryPolicy: v1alpha1.DBaaSInventoryPolicy{
+					AllowedCloudRegions: &[]string{"us-east-1"},
+				},
 			},
 		}
 		lastTransitionTime := getLastTransitionTimeForTest()
@@ -456,6 +462,78 @@ var _ = Describe("Check inventory", func() {
 				})
 			})
 		})
+
+		When("check an instance outside of the allowed cloud regions", func() {
+			It("should report the policy violation reason", func() {
+				instance := &v1alpha1.DBaaSInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-check-inventory-instance",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.DBaaSInstanceSpec{
+						InventoryRef: v1alpha1.NamespacedName{
+							Name:      createdDBaaSInventory2.Name,
+							Namespace: testNamespace,
+						},
+						Name:        "test-check-inventory-instance",
+						CloudRegion: "eu-west-1",
+					},
+				}
+				_, validNS, provision, err := dRec.checkInventory(ctx, instance.Spec.InventoryRef, instance, func(reason string, message string) {
+					apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+						Type:    v1alpha1.DBaaSInstanceReadyType,
+						Status:  metav1.ConditionFalse,
+						Reason:  reason,
+						Message: message,
+					})
+				}, ctrl.LoggerFrom(ctx))
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(validNS).To(BeTrue())
+				Expect(provision).To(BeFalse())
+				cond := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceReadyType)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Reason).To(Equal(v1alpha1.DBaaSInstancePolicyViolation))
+				Expect(cond.Message).To(Equal("spec.cloudRegion: Unsupported value: \"eu-west-1\": supported values: \"us-east-1\""))
+			})
+
+			It("should not enforce the policy on a provisioned instance", func() {
+				instance := &v1alpha1.DBaaSInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-check-inventory-provisioned-instance",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.DBaaSInstanceSpec{
+						InventoryRef: v1alpha1.NamespacedName{
+							Name:      createdDBaaSInventory2.Name,
+							Namespace: testNamespace,
+						},
+						Name:        "test-check-inventory-provisioned-instance",
+						CloudRegion: "eu-west-1",
+					},
+					Status: v1alpha1.DBaaSInstanceStatus{
+						Conditions: []metav1.Condition{{
+							Type:   v1alpha1.DBaaSInstanceProviderSyncType,
+							Status: metav1.ConditionTrue,
+							Reason: v1alpha1.Ready,
+						}},
+					},
+				}
+				_, validNS, provision, err := dRec.checkInventory(ctx, instance.Spec.InventoryRef, instance, func(reason string, message string) {
+					apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+						Type:    v1alpha1.DBaaSInstanceReadyType,
+						Status:  metav1.ConditionFalse,
+						Reason:  reason,
+						Message: message,
+					})
+				}, ctrl.LoggerFrom(ctx))
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(validNS).To(BeTrue())
+				Expect(provision).To(BeTrue())
+				Expect(apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceReadyType)).To(BeNil())
+			})
+		})
 	})
 
 	Context("after creating not ready DBaaSInventory", func() {
@@ -0,0 +1,107 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// DBaaSBackupReconciler reconciles a DBaaSBackup object
+type DBaaSBackupReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
+func (r *DBaaSBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var backup v1alpha1.DBaaSBackup
+	if err := r.Get(ctx, req.NamespacedName, &backup); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("DBaaS Backup resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Backup for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	setStatusError := func(reason string, message string) {
+		cond := metav1.Condition{
+			Type:    v1alpha1.DBaaSBackupReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}
+		apimeta.SetStatusCondition(&backup.Status.Conditions, cond)
+		backup.Status.Phase = v1alpha1.BackupPhaseError
+	}
+
+	return r.reconcileProviderOperation(ctx, backup.Spec.InventoryRef, &backup, setStatusError,
+		func(provider *v1alpha1.DBaaSProvider) string {
+			return provider.Spec.BackupKind
+		},
+		v1alpha1.DBaaSBackupNotSupported, v1alpha1.MsgBackupNotSupported,
+		func() interface{} {
+			return backup.Spec.DeepCopy()
+		},
+		func() interface{} {
+			return &v1alpha1.DBaaSProviderBackup{}
+		},
+		func(i interface{}) metav1.Condition {
+			providerBackup := i.(*v1alpha1.DBaaSProviderBackup)
+			return mergeBackupStatus(&backup, providerBackup)
+		},
+		func() *[]metav1.Condition {
+			return &backup.Status.Conditions
+		},
+		v1alpha1.DBaaSBackupReadyType,
+		logger,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSBackupReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBaaSBackup{}).
+		Build(r)
+}
+
+// mergeBackupStatus: merge the status from DBaaSProviderBackup into the current DBaaSBackup status
+func mergeBackupStatus(backup *v1alpha1.DBaaSBackup, providerBackup *v1alpha1.DBaaSProviderBackup) metav1.Condition {
+	providerBackup.Status.DeepCopyInto(&backup.Status)
+	if len(backup.Status.Phase) == 0 {
+		backup.Status.Phase = v1alpha1.BackupPhaseUnknown
+	}
+	return providerSyncCondition(providerBackup.Status.Conditions, v1alpha1.DBaaSBackupProviderSyncType, v1alpha1.DBaaSBackupReadyType)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+var backupProvider = &v1alpha1.DBaaSProvider{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "mongodb-atlas-backup",
+	},
+	Spec: v1alpha1.DBaaSProviderSpec{
+		Provider: v1alpha1.DatabaseProvider{
+			Name: "mongodb-atlas-backup",
+		},
+		InventoryKind:                testInventoryKind,
+		ConnectionKind:               testConnectionKind,
+		InstanceKind:                 testInstanceKind,
+		BackupKind:                   testBackupKind,
+		RestoreKind:                  testRestoreKind,
+		CredentialFields:             []v1alpha1.CredentialField{},
+		AllowsFreeTrial:              false,
+		ExternalProvisionURL:         "",
+		ExternalProvisionDescription: "",
+		InstanceParameterSpecs:       []v1alpha1.InstanceParameterSpec{},
+	},
+}
+
+func getBackupTestInventory(name, providerName string) *v1alpha1.DBaaSInventory {
+	return &v1alpha1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: v1alpha1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1alpha1.NamespacedName{
+				Name: providerName,
+			},
+			DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+				CredentialsRef: &v1alpha1.LocalObjectReference{
+					Name: testSecret.Name,
+				},
+			},
+		},
+	}
+}
+
+func getBackupTestInventoryStatus() *v1alpha1.DBaaSInventoryStatus {
+	return &v1alpha1.DBaaSInventoryStatus{
+		Instances: []v1alpha1.Instance{
+			{
+				InstanceID: "testInstanceID",
+				Name:       "testInstance",
+			},
+		},
+		Conditions: []metav1.Condition{
+			{
+				Type:               "SpecSynced",
+				Status:             metav1.ConditionTrue,
+				Reason:             "SyncOK",
+				LastTransitionTime: metav1.Time{Time: getLastTransitionTimeForTest()},
+			},
+		},
+	}
+}
+
+var _ = Describe("DBaaSBackup controller", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Context("after creating DBaaSBackup without inventory", func() {
+		createdDBaaSBackup := &v1alpha1.DBaaSBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup-no-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSBackupSpec{
+				InventoryRef: v1alpha1.NamespacedName{
+					Name:      "test-backup-inventory-no-exist",
+					Namespace: testNamespace,
+				},
+				InstanceID: "testInstanceID",
+			},
+		}
+		BeforeEach(assertResourceCreation(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSBackup))
+		It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSBackup, metav1.ConditionFalse, v1alpha1.DBaaSInventoryNotFound))
+	})
+
+	Context("after creating DBaaSBackup against a provider without backup support", func() {
+		createdDBaaSInventory := getBackupTestInventory("test-backup-inventory-unsupported", testProviderName)
+		createdDBaaSBackup := &v1alpha1.DBaaSBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup-unsupported",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSBackupSpec{
+				InventoryRef: v1alpha1.NamespacedName{
+					Name:      createdDBaaSInventory.Name,
+					Namespace: testNamespace,
+				},
+				InstanceID: "testInstanceID",
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, getBackupTestInventoryStatus()))
+		BeforeEach(assertResourceCreation(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSBackup, metav1.ConditionFalse, v1alpha1.DBaaSBackupNotSupported))
+	})
+
+	Context("after creating DBaaSBackup against a provider with backup support", func() {
+		createdDBaaSInventory := getBackupTestInventory("test-backup-inventory", backupProvider.Name)
+		DBaaSBackupSpec := &v1alpha1.DBaaSBackupSpec{
+			InventoryRef: v1alpha1.NamespacedName{
+				Name:      createdDBaaSInventory.Name,
+				Namespace: testNamespace,
+			},
+			InstanceID: "testInstanceID",
+			OtherBackupParams: map[string]string{
+				"testParam": "test-param",
+			},
+		}
+		createdDBaaSBackup := &v1alpha1.DBaaSBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup",
+				Namespace: testNamespace,
+			},
+			Spec: *DBaaSBackupSpec,
+		}
+		BeforeEach(assertResourceCreationIfNotExists(backupProvider))
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, getBackupTestInventoryStatus()))
+		BeforeEach(assertResourceCreation(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		It("should create a provider backup", assertProviderResourceCreated(createdDBaaSBackup, testBackupKind, DBaaSBackupSpec))
+	})
+})
@@ -22,7 +22,10 @@ import (
 	"reflect"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appv1 "k8s.io/api/apps/v1"
@@ -94,6 +97,22 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		SetConnectionMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, connection, execution)
 		return ctrl.Result{}, nil
 	} else {
+		if paused, err := r.isInstancePaused(ctx, &connection); err != nil {
+			logger.Error(err, "Error reading the instance of the DBaaS Connection")
+			SetConnectionMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, connection, execution)
+			return ctrl.Result{}, err
+		} else if paused {
+			logger.Info("DBaaS Instance of the DBaaS Connection is paused")
+			cond := metav1.Condition{
+				Type:    v1alpha1.DBaaSConnectionReadyType,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.DBaaSInstancePaused,
+				Message: v1alpha1.MsgInstancePaused,
+			}
+			r.updateConnectionStatus(ctx, &connection, &cond)
+			SetConnectionMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, connection, execution)
+			return ctrl.Result{}, nil
+		}
 		spec, err := r.getConnectionSpec(ctx, connection.Spec.DeepCopy())
 		if err != nil {
 			logger.Error(err, "Cannot read the instance reference")
@@ -136,9 +155,21 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSConnectionReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
-	return ctrl.NewControllerManagedBy(mgr).
+	// index connection by `spec.instanceRef`, to find the connections referencing an instance
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DBaaSConnection{}, instanceRefKey, func(rawObj client.Object) []string {
+		connection := rawObj.(*v1alpha1.DBaaSConnection)
+		if connection.Spec.InstanceRef == nil {
+			return nil
+		}
+		return []string{instanceRefIndexValue(connection.Spec.InstanceRef.Namespace, connection.Spec.InstanceRef.Name)}
+	}); err != nil {
+		return nil, err
+	}
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBaaSConnection{}).
 		Watches(&source.Kind{Type: &v1alpha1.DBaaSConnection{}}, &EventHandlerWithDelete{Controller: r}).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.instanceMapFn))
+	return r.watchNamespacePolicies(builder, func() client.ObjectList { return &v1alpha1.DBaaSConnectionList{} }).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
@@ -254,7 +285,12 @@ func (r *DBaaSConnectionReconciler) getConnectionSpec(ctx context.Context, spec
 		return nil, fmt.Errorf("cannot read the instance reference")
 	}
 
-	if !reflect.DeepEqual(instance.Spec.InventoryRef, spec.InventoryRef) {
+	instanceSpec, _, err := r.getInstanceSpec(ctx, instance)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the instance class of the instance reference")
+	}
+
+	if !reflect.DeepEqual(instanceSpec.InventoryRef, spec.InventoryRef) {
 		return nil, fmt.Errorf("instance and connection don't use the same inventory reference")
 	}
 
@@ -267,6 +303,61 @@ func (r *DBaaSConnectionReconciler) getConnectionSpec(ctx context.Context, spec
 	return spec, nil
 }
 
+// instanceMapFn maps a DBaaSInstance to the DBaaSConnections referencing it
+func (r *DBaaSConnectionReconciler) instanceMapFn(o client.Object) []reconcile.Request {
+	ctx := context.Background()
+	logger := ctrl.Log.WithName("DBaaSConnectionReconciler")
+	instance := o.(*v1alpha1.DBaaSInstance)
+	instanceSpec, _, err := r.getInstanceSpec(ctx, instance)
+	if err != nil {
+		logger.V(1).Info("Cannot read the instance class of the DBaaS Instance", "DBaaS Instance", client.ObjectKeyFromObject(instance))
+		return nil
+	}
+	connections, err := listInstanceConnections(ctx, r.Client, instance, instanceSpec.InventoryRef)
+	if err != nil {
+		logger.Error(err, "Error listing DBaaS Connections for DBaaS Instance", "DBaaS Instance", client.ObjectKeyFromObject(instance))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range connections {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&connections[i])})
+	}
+	return requests
+}
+
+// isInstancePaused checks whether the DBaaSInstance referenced by a connection is paused
+func (r *DBaaSConnectionReconciler) isInstancePaused(ctx context.Context, connection *v1alpha1.DBaaSConnection) (bool, error) {
+	var instances []v1alpha1.DBaaSInstance
+	if ref := connection.Spec.InstanceRef; ref != nil {
+		instance := v1alpha1.DBaaSInstance{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, &instance); err != nil {
+			// a missing instance is reported by getConnectionSpec
+			return false, client.IgnoreNotFound(err)
+		}
+		instances = append(instances, instance)
+	} else if len(connection.Spec.InstanceID) > 0 {
+		var instanceList v1alpha1.DBaaSInstanceList
+		if err := r.List(ctx, &instanceList, client.MatchingFields{instanceIDKey: connection.Spec.InstanceID}); err != nil {
+			return false, err
+		}
+		instances = instanceList.Items
+	}
+	for i := range instances {
+		instance := &instances[i]
+		if instance.Status.PowerState != v1alpha1.InstancePowerStatePaused && instance.Status.Phase != v1alpha1.InstancePhasePaused {
+			continue
+		}
+		instanceSpec, _, err := r.getInstanceSpec(ctx, instance)
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		if isConnectionToInstance(connection, instance, instanceSpec.InventoryRef) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (r *DBaaSConnectionReconciler) updateConnectionStatus(ctx context.Context, connection *v1alpha1.DBaaSConnection, cond *metav1.Condition) {
 	apimeta.SetStatusCondition(&connection.Status.Conditions, *cond)
 	logger := ctrl.LoggerFrom(ctx)
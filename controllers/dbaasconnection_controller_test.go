@@ -22,6 +22,7 @@ import (
 
 	appv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -616,6 +617,174 @@ var _ = Describe("DBaaSConnection controller - nominal with instance reference",
 	})
 })
 
+var _ = Describe("DBaaSConnection controller - namespace policy changes", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Context("after creating a DBaaSConnection in a namespace selected by the inventory", func() {
+		teardown := true
+		otherNS := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "other-revoked",
+				Labels: map[string]string{"dbaas-test": "revoked"},
+			},
+		}
+		createdDBaaSInventory := &v1alpha1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-inventory-revoked",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1alpha1.NamespacedName{
+					Name: testProviderName,
+				},
+				DBaaSInventoryPolicy: v1alpha1.DBaaSInventoryPolicy{
+					ConnectionNsSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"dbaas-test": "revoked"},
+					},
+					TeardownOnInvalidNamespace: &teardown,
+				},
+				DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+					CredentialsRef: &v1alpha1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+			},
+		}
+		providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               "SpecSynced",
+					Status:             metav1.ConditionTrue,
+					Reason:             "SyncOK",
+					LastTransitionTime: metav1.Time{Time: getLastTransitionTimeForTest()},
+				},
+			},
+		}
+		DBaaSConnectionSpec := &v1alpha1.DBaaSConnectionSpec{
+			InventoryRef: v1alpha1.NamespacedName{
+				Name:      createdDBaaSInventory.Name,
+				Namespace: testNamespace,
+			},
+			InstanceID: "test-instanceID",
+		}
+		createdDBaaSConnection := &v1alpha1.DBaaSConnection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-connection-revoked",
+				Namespace: otherNS.Name,
+			},
+			Spec: *DBaaSConnectionSpec,
+		}
+		BeforeEach(assertResourceCreationIfNotExists(otherNS))
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+		BeforeEach(assertResourceCreation(createdDBaaSConnection))
+		AfterEach(assertResourceDeletion(createdDBaaSConnection))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+
+		It("should invalidate the connection and delete its provider object when the namespace is relabeled", func() {
+			assertProviderResourceCreated(createdDBaaSConnection, testConnectionKind, DBaaSConnectionSpec)()
+
+			By("removing the selected label from the namespace")
+			ns := &v1.Namespace{}
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(otherNS), ns)).Should(Succeed())
+			ns.Labels = nil
+			Expect(dRec.Update(ctx, ns)).Should(Succeed())
+
+			assertDBaaSResourceStatusUpdated(createdDBaaSConnection, metav1.ConditionFalse, v1alpha1.DBaaSInvalidNamespace)()
+			Eventually(func() bool {
+				providerConnection := dRec.createProviderObject(createdDBaaSConnection, testConnectionKind)
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSConnection), providerConnection)
+				return errors.IsNotFound(err)
+			}, timeout).Should(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("DBaaSConnection controller - restricting policy", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Context("after creating a DBaaSConnection in a namespace allowed by the default policy", func() {
+		otherNS := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-restricted",
+			},
+		}
+		createdDBaaSInventory := &v1alpha1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-inventory-restricted",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1alpha1.NamespacedName{
+					Name: testProviderName,
+				},
+				DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+					CredentialsRef: &v1alpha1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+			},
+		}
+		providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               "SpecSynced",
+					Status:             metav1.ConditionTrue,
+					Reason:             "SyncOK",
+					LastTransitionTime: metav1.Time{Time: getLastTransitionTimeForTest()},
+				},
+			},
+		}
+		DBaaSConnectionSpec := &v1alpha1.DBaaSConnectionSpec{
+			InventoryRef: v1alpha1.NamespacedName{
+				Name:      createdDBaaSInventory.Name,
+				Namespace: testNamespace,
+			},
+			InstanceID: "test-instanceID",
+		}
+		createdDBaaSConnection := &v1alpha1.DBaaSConnection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-connection-restricted",
+				Namespace: otherNS.Name,
+			},
+			Spec: *DBaaSConnectionSpec,
+		}
+		restrictingPolicy := &v1alpha1.DBaaSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policy-restricting",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSPolicySpec{
+				Priority: 100,
+				DBaaSInventoryPolicy: v1alpha1.DBaaSInventoryPolicy{
+					ConnectionNamespaces: &[]string{testNamespace},
+				},
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(otherNS))
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+		BeforeEach(assertResourceCreation(createdDBaaSConnection))
+		AfterEach(assertResourceDeletion(restrictingPolicy))
+		AfterEach(assertResourceDeletion(createdDBaaSConnection))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+
+		It("should invalidate the connection once a restricting policy is created and Ready", func() {
+			assertProviderResourceCreated(createdDBaaSConnection, testConnectionKind, DBaaSConnectionSpec)()
+
+			By("creating a higher priority policy that no longer allows the namespace of the connection")
+			assertResourceCreation(restrictingPolicy)()
+			assertDBaaSResourceStatusUpdated(restrictingPolicy, metav1.ConditionTrue, v1alpha1.Ready)()
+
+			assertDBaaSResourceStatusUpdated(createdDBaaSConnection, metav1.ConditionFalse, v1alpha1.DBaaSInvalidNamespace)()
+		})
+	})
+})
+
 var _ = Describe("DBaaSConnection controller - valid dev namespaces", func() {
 	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
 	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
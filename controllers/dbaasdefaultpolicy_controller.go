@@ -15,6 +15,10 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
@@ -28,9 +32,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
+// DefaultPolicyEnvVar is the env variable holding the spec of the default DBaaSPolicy, as JSON
+const DefaultPolicyEnvVar = "DEFAULT_POLICY"
+
 // DBaaSDefaultPolicyReconciler reconciles a default DBaaSPolicy object
 type DBaaSDefaultPolicyReconciler struct {
 	*DBaaSReconciler
+	// DefaultPolicySpec is the spec of the default policy, the default policy allows connections from all namespaces if not set
+	DefaultPolicySpec *v1alpha1.DBaaSPolicySpec
 }
 
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
@@ -81,6 +90,9 @@ func (r *DBaaSDefaultPolicyReconciler) ignoreOtherDeployments() predicate.Predic
 func (r *DBaaSDefaultPolicyReconciler) createDefaultPolicy(ctx context.Context) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	defaultPolicy := getDefaultPolicy(r.InstallNamespace)
+	if r.DefaultPolicySpec != nil {
+		defaultPolicy.Spec = *r.DefaultPolicySpec.DeepCopy()
+	}
 
 	// get list of DBaaSPolicies for install/default namespace
 	policyList, err := r.policyListByNS(ctx, defaultPolicy.Namespace)
@@ -110,6 +122,21 @@ func (r *DBaaSDefaultPolicyReconciler) createDefaultPolicy(ctx context.Context)
 	return ctrl.Result{}, nil
 }
 
+// GetDefaultPolicySpec returns the spec of the default policy set by the DEFAULT_POLICY env variable, or nil if not set
+func GetDefaultPolicySpec() (*v1alpha1.DBaaSPolicySpec, error) {
+	value, found := os.LookupEnv(DefaultPolicyEnvVar)
+	if !found || len(value) == 0 {
+		return nil, nil
+	}
+	spec := &v1alpha1.DBaaSPolicySpec{}
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DefaultPolicyEnvVar, err)
+	}
+	return spec, nil
+}
+
 func getDefaultPolicy(inventoryNamespace string) v1alpha1.DBaaSPolicy {
 	policy := v1alpha1.DBaaSPolicy{
 		ObjectMeta: metav1.ObjectMeta{
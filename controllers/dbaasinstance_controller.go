@@ -18,9 +18,17 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -30,16 +38,35 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/util"
+)
+
+const (
+	instanceClassNameKey = "spec.instanceClassName"
+	instanceIDKey        = "status.instanceID"
+	instanceRefKey       = "spec.instanceRef"
+
+	// instancePhaseHistoryLimit is the maximum number of phase transitions kept in the instance status
+	instancePhaseHistoryLimit = 10
+
+	// defaultExpirationWarningPeriod is how long before its expiration an instance reports that it is expiring
+	defaultExpirationWarningPeriod = time.Hour
+	// policyExpirationGracePeriod is the minimum time an instance reports that it is expiring before a maximum time
+	// to live added or lowered by its policy deletes it
+	policyExpirationGracePeriod = time.Hour
 )
 
 // DBaaSInstanceReconciler reconciles a DBaaSInstance object
 type DBaaSInstanceReconciler struct {
 	*DBaaSReconciler
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -61,7 +88,44 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
-	if inventory, validNS, provision, err := r.checkInventory(ctx, instance.Spec.InventoryRef, &instance, func(reason string, message string) {
+	spec, instanceClass, err := r.getInstanceSpec(ctx, &instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Error(err, "DBaaS Instance Class resource not found for DBaaS Instance", "DBaaS Instance Class", instance.Spec.InstanceClassName)
+			r.updateInstanceStatus(ctx, &instance, v1alpha1.DBaaSInstanceClassNotFound, err.Error())
+		} else {
+			logger.Error(err, "Error fetching DBaaS Instance Class for DBaaS Instance", "DBaaS Instance Class", instance.Spec.InstanceClassName)
+		}
+		return ctrl.Result{}, err
+	}
+
+	expirationTime, err := r.getInstanceExpiration(ctx, &instance, spec)
+	if err != nil {
+		logger.Error(err, "Error reading the expiration of the DBaaS Instance")
+		return ctrl.Result{}, err
+	}
+	if expirationTime != nil && !expirationTime.After(time.Now()) {
+		if err := r.deleteExpiredInstance(ctx, &instance, spec); err != nil {
+			logger.Error(err, "Error deleting the expired DBaaS Instance")
+			return ctrl.Result{}, err
+		}
+		logger.Info("Expired DBaaS Instance deleted", "Expiration Time", expirationTime)
+		return ctrl.Result{}, nil
+	}
+	// the expiration is handled by the operator, providers do not receive it
+	spec.TTL, spec.ExpirationTime, spec.ExpirationWarningPeriod = nil, nil, nil
+	expiringCond := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceExpiringType)
+
+	powerState, nextPowerChange, err := getInstancePowerState(spec, time.Now())
+	if err != nil {
+		logger.Error(err, "Invalid power schedule for DBaaS Instance")
+		r.updateInstanceStatus(ctx, &instance, v1alpha1.DBaaSInvalidPowerSchedule, err.Error())
+		return ctrl.Result{}, nil
+	}
+	// the power schedules are handled by the operator, providers only receive the resulting power state
+	spec.PowerState, spec.PowerSchedules = powerState, nil
+
+	if inventory, validNS, provision, err := r.checkInventory(ctx, spec.InventoryRef, &instance, func(reason string, message string) {
 		cond := metav1.Condition{
 			Type:    v1alpha1.DBaaSInstanceReadyType,
 			Status:  metav1.ConditionFalse,
@@ -70,6 +134,7 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 		apimeta.SetStatusCondition(&instance.Status.Conditions, cond)
 		instance.Status.Phase = v1alpha1.InstancePhaseError
+		recordInstancePhase(&instance, reason)
 	}, logger); err != nil {
 		SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
 		return ctrl.Result{}, err
@@ -79,7 +144,39 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	} else if !provision {
 		SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
 		return ctrl.Result{}, nil
+	} else if instanceClass != nil && instanceClass.Spec.ProviderRef.Name != inventory.Spec.ProviderRef.Name {
+		logger.Info("DBaaS Instance Class does not apply to the provider of the inventory", "DBaaS Instance Class", instanceClass.Name, "DBaaS Provider", inventory.Spec.ProviderRef.Name)
+		r.updateInstanceStatus(ctx, &instance, v1alpha1.DBaaSInstanceClassInvalid, v1alpha1.MsgInstanceClassInvalid)
+		SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
+		return ctrl.Result{}, nil
 	} else {
+		if reason, message, err := r.checkProviderCapabilities(ctx, inventory.Spec.ProviderRef.Name, spec); err != nil {
+			logger.Error(err, "Error reading configured DBaaSProvider", "DBaaS Provider", inventory.Spec.ProviderRef.Name)
+			SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
+			return ctrl.Result{}, err
+		} else if len(reason) > 0 {
+			logger.Info("DBaaS Provider does not support the DBaaS Instance spec", "DBaaS Provider", inventory.Spec.ProviderRef.Name, "Reason", reason)
+			r.updateInstanceStatus(ctx, &instance, reason, message)
+			SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
+			return ctrl.Result{}, nil
+		}
+		if approved, err := r.checkInstanceApproval(ctx, &instance, inventory); err != nil {
+			logger.Error(err, "Error checking the approval of the DBaaS Instance")
+			SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
+			return ctrl.Result{}, err
+		} else if !approved {
+			logger.Info("DBaaS Instance is not approved", "Phase", instance.Status.Phase)
+			SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
+			return ctrl.Result{}, nil
+		}
+		if spec.CloneSource != nil {
+			if err := r.resolveCloneSource(ctx, spec); err != nil {
+				logger.Error(err, "Cannot read the clone source")
+				r.updateInstanceStatus(ctx, &instance, v1alpha1.DBaaSCloneSourceNotAvailable, err.Error())
+				SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
+				return ctrl.Result{}, err
+			}
+		}
 		result, err := r.reconcileProviderResource(ctx,
 			inventory.Spec.ProviderRef.Name,
 			&instance,
@@ -87,14 +184,23 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				return provider.Spec.InstanceKind
 			},
 			func() interface{} {
-				return instance.Spec.DeepCopy()
+				return spec
 			},
 			func() interface{} {
 				return &v1alpha1.DBaaSProviderInstance{}
 			},
 			func(i interface{}) metav1.Condition {
 				providerInstance := i.(*v1alpha1.DBaaSProviderInstance)
-				return mergeInstanceStatus(&instance, providerInstance)
+				instance.Status.PowerState = powerState
+				cond := mergeInstanceStatus(&instance, providerInstance)
+				r.setInstanceExpiration(&instance, expirationTime, expiringCond)
+				if instanceClass != nil {
+					instance.Status.InstanceClass = &v1alpha1.AppliedInstanceClass{
+						Name:       instanceClass.Name,
+						Generation: instanceClass.Generation,
+					}
+				}
+				return cond
 			},
 			func() *[]metav1.Condition {
 				return &instance.Status.Conditions
@@ -103,29 +209,447 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			logger,
 		)
 		SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution)
+		// the expiration and power schedule checks are kept whatever the outcome of the provider sync,
+		// an earlier requeue requested by the sync still takes precedence
+		if expirationTime != nil {
+			result = mergeRequeueAfter(result, nextExpirationCheck(&instance, expirationTime))
+		}
+		if nextPowerChange != nil {
+			result = mergeRequeueAfter(result, time.Until(*nextPowerChange))
+		}
 		return result, err
 	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSInstanceReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
-	return ctrl.NewControllerManagedBy(mgr).
+	// index instance by `spec.instanceClassName`
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DBaaSInstance{}, instanceClassNameKey, func(rawObj client.Object) []string {
+		instance := rawObj.(*v1alpha1.DBaaSInstance)
+		return []string{instance.Spec.InstanceClassName}
+	}); err != nil {
+		return nil, err
+	}
+	// index instance by `status.instanceID`, to find the instances referenced by ID from connections
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DBaaSInstance{}, instanceIDKey, func(rawObj client.Object) []string {
+		instance := rawObj.(*v1alpha1.DBaaSInstance)
+		if len(instance.Status.InstanceID) == 0 {
+			return nil
+		}
+		return []string{instance.Status.InstanceID}
+	}); err != nil {
+		return nil, err
+	}
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBaaSInstance{}).
 		Watches(&source.Kind{Type: &v1alpha1.DBaaSInstance{}}, &EventHandlerWithDelete{Controller: r}).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSInstanceClass{}}, handler.EnqueueRequestsFromMapFunc(r.instanceClassMapFn)).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSInstanceApproval{}}, handler.EnqueueRequestsFromMapFunc(instanceApprovalMapFn))
+	return r.watchNamespacePolicies(builder, func() client.ObjectList { return &v1alpha1.DBaaSInstanceList{} }).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
 		Build(r)
 }
 
+// instanceClassMapFn maps a DBaaSInstanceClass to the DBaaSInstances referencing it
+func (r *DBaaSInstanceReconciler) instanceClassMapFn(o client.Object) []reconcile.Request {
+	var instanceList v1alpha1.DBaaSInstanceList
+	if err := r.List(context.Background(), &instanceList, client.MatchingFields{instanceClassNameKey: o.GetName()}); err != nil {
+		ctrl.Log.WithName("DBaaSInstanceReconciler").Error(err, "Error listing DBaaS Instances for DBaaS Instance Class", "DBaaS Instance Class", o.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(instanceList.Items))
+	for i := range instanceList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&instanceList.Items[i])})
+	}
+	return requests
+}
+
+// instanceApprovalMapFn maps a DBaaSInstanceApproval to the DBaaSInstance it approves
+func instanceApprovalMapFn(o client.Object) []reconcile.Request {
+	approval := o.(*v1alpha1.DBaaSInstanceApproval)
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: approval.Namespace, Name: approval.Spec.InstanceRef.Name}}}
+}
+
+// checkInstanceApproval checks whether an instance may be provisioned when its inventory policy requires approval.
+// the instance awaits approval until a DBaaSInstanceApproval created after it decides on it, the most recent
+// decision taking precedence, as long as its approver is still allowed to approve the instance. instances provisioned before the policy required approval are not affected.
+func (r *DBaaSInstanceReconciler) checkInstanceApproval(ctx context.Context, instance *v1alpha1.DBaaSInstance, inventory *v1alpha1.DBaaSInventory) (bool, error) {
+	if approval := instance.Status.Approval; approval != nil && approval.Decision == v1alpha1.InstanceApprovalApproved {
+		return true, nil
+	}
+	if apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceProviderSyncType) != nil {
+		return true, nil
+	}
+	policy, err := v1alpha1.GetInventoryPolicy(ctx, r.Client, inventory)
+	if err != nil {
+		return false, err
+	}
+	if policy.RequireInstanceApproval == nil || !*policy.RequireInstanceApproval {
+		apimeta.RemoveStatusCondition(&instance.Status.Conditions, v1alpha1.DBaaSInstanceApprovalType)
+		return true, nil
+	}
+
+	approval, err := r.getInstanceApproval(ctx, instance)
+	if err != nil {
+		return false, err
+	}
+	authorized := false
+	if approval != nil {
+		// the approver may have lost the permission to approve the instance since the admission of the approval
+		if authorized, err = v1alpha1.CanApproveInstance(ctx, r.Client, instance, approval.Spec.Approver, approval.Spec.ApproverGroups, nil); err != nil {
+			return false, err
+		}
+	}
+	var approvalCond, readyCond metav1.Condition
+	switch {
+	case approval == nil:
+		approvalCond = metav1.Condition{Status: metav1.ConditionTrue, Reason: v1alpha1.InstanceApprovalPending, Message: v1alpha1.MsgInstanceAwaitingApproval}
+		readyCond = metav1.Condition{Reason: v1alpha1.DBaaSInstanceAwaitingApproval, Message: v1alpha1.MsgInstanceAwaitingApproval}
+		instance.Status.Phase = v1alpha1.InstancePhasePending
+	case !authorized:
+		approvalCond = metav1.Condition{Status: metav1.ConditionTrue, Reason: v1alpha1.InstanceApproverUnauthorized, Message: v1alpha1.MsgInstanceApproverUnauthorized}
+		readyCond = metav1.Condition{Reason: v1alpha1.DBaaSInstanceAwaitingApproval, Message: v1alpha1.MsgInstanceApproverUnauthorized}
+		instance.Status.Phase = v1alpha1.InstancePhasePending
+	case approval.Spec.Decision == v1alpha1.InstanceApprovalApproved:
+		approvalCond = metav1.Condition{Status: metav1.ConditionFalse, Reason: string(v1alpha1.InstanceApprovalApproved), Message: v1alpha1.MsgInstanceApproved}
+	default:
+		approvalCond = metav1.Condition{Status: metav1.ConditionFalse, Reason: string(v1alpha1.InstanceApprovalRejected), Message: v1alpha1.MsgInstanceRejected}
+		readyCond = metav1.Condition{Reason: v1alpha1.DBaaSInstanceRejected, Message: v1alpha1.MsgInstanceRejected}
+		instance.Status.Phase = v1alpha1.InstancePhaseFailed
+	}
+	if approval != nil && authorized {
+		instance.Status.Approval = &v1alpha1.DBaaSInstanceApprovalRecord{
+			Name:         approval.Name,
+			Decision:     approval.Spec.Decision,
+			Approver:     approval.Spec.Approver,
+			Message:      approval.Spec.Message,
+			DecisionTime: approval.CreationTimestamp,
+		}
+	}
+	approvalCond.Type = v1alpha1.DBaaSInstanceApprovalType
+	apimeta.SetStatusCondition(&instance.Status.Conditions, approvalCond)
+	if len(readyCond.Reason) == 0 {
+		// approved, the status is updated with the provider status
+		return true, nil
+	}
+	readyCond.Type, readyCond.Status = v1alpha1.DBaaSInstanceReadyType, metav1.ConditionFalse
+	apimeta.SetStatusCondition(&instance.Status.Conditions, readyCond)
+	recordInstancePhase(instance, readyCond.Reason)
+	if err := r.Client.Status().Update(ctx, instance); err != nil && !errors.IsConflict(err) {
+		return false, err
+	}
+	return false, nil
+}
+
+// getInstanceApproval returns the most recent DBaaSInstanceApproval of an instance, created after the instance
+func (r *DBaaSInstanceReconciler) getInstanceApproval(ctx context.Context, instance *v1alpha1.DBaaSInstance) (*v1alpha1.DBaaSInstanceApproval, error) {
+	approvalList := &v1alpha1.DBaaSInstanceApprovalList{}
+	if err := r.List(ctx, approvalList, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+	var latest *v1alpha1.DBaaSInstanceApproval
+	for i := range approvalList.Items {
+		approval := &approvalList.Items[i]
+		// approvals of a deleted instance of the same name do not apply
+		if approval.Spec.InstanceRef.Name != instance.Name || approval.CreationTimestamp.Before(&instance.CreationTimestamp) {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&approval.CreationTimestamp) ||
+			(latest.CreationTimestamp.Equal(&approval.CreationTimestamp) && latest.Name < approval.Name) {
+			latest = approval
+		}
+	}
+	return latest, nil
+}
+
+// checkProviderCapabilities checks that the provider supports the features requested by the instance spec.
+// returns the reason and message of the unsupported feature, if any. a missing provider is reported by reconcileProviderResource.
+func (r *DBaaSInstanceReconciler) checkProviderCapabilities(ctx context.Context, providerName string, spec *v1alpha1.DBaaSInstanceSpec) (string, string, error) {
+	if spec.CloneSource == nil && spec.PowerState != v1alpha1.InstancePowerStatePaused {
+		return "", "", nil
+	}
+	provider, err := r.getDBaaSProvider(ctx, providerName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	if spec.CloneSource != nil && !provider.Spec.AllowsClone {
+		return v1alpha1.DBaaSCloneNotSupported, v1alpha1.MsgCloneNotSupported, nil
+	}
+	if spec.PowerState == v1alpha1.InstancePowerStatePaused && !provider.Spec.AllowsPause {
+		return v1alpha1.DBaaSPauseNotSupported, v1alpha1.MsgPauseNotSupported, nil
+	}
+	return "", "", nil
+}
+
+// getInstancePowerState returns the power state of an instance at a given time, and the time of the next
+// activation of its power schedules, if any. the most recent schedule activation takes precedence over the
+// desired power state of the instance.
+func getInstancePowerState(spec *v1alpha1.DBaaSInstanceSpec, now time.Time) (v1alpha1.DBaaSInstancePowerState, *time.Time, error) {
+	powerState := spec.PowerState
+	var lastActivation time.Time
+	var nextActivation *time.Time
+	for _, powerSchedule := range spec.PowerSchedules {
+		schedule, err := util.ParseSchedule(powerSchedule.Schedule)
+		if err != nil {
+			return "", nil, err
+		}
+		if prev := schedule.Prev(now); !prev.IsZero() && prev.After(lastActivation) {
+			lastActivation = prev
+			powerState = powerSchedule.PowerState
+		}
+		if next := schedule.Next(now); !next.IsZero() && (nextActivation == nil || next.Before(*nextActivation)) {
+			nextActivation = &next
+		}
+	}
+	return powerState, nextActivation, nil
+}
+
+// resolveCloneSource replaces the instance reference of the clone source by the instance ID of the referenced instance
+func (r *DBaaSInstanceReconciler) resolveCloneSource(ctx context.Context, spec *v1alpha1.DBaaSInstanceSpec) error {
+	sourceRef := spec.CloneSource.InstanceRef
+	if sourceRef == nil {
+		if len(spec.CloneSource.InstanceID) == 0 {
+			return fmt.Errorf("clone source is not properly set")
+		}
+		return nil
+	}
+
+	source := &v1alpha1.DBaaSInstance{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      sourceRef.Name,
+		Namespace: sourceRef.Namespace,
+	}, source); err != nil {
+		return fmt.Errorf("cannot read the clone source instance")
+	}
+
+	sourceSpec, _, err := r.getInstanceSpec(ctx, source)
+	if err != nil {
+		return fmt.Errorf("cannot read the instance class of the clone source instance")
+	}
+
+	if !reflect.DeepEqual(sourceSpec.InventoryRef, spec.InventoryRef) {
+		return fmt.Errorf("instance and clone source don't use the same inventory reference")
+	}
+
+	if len(source.Status.InstanceID) == 0 {
+		return fmt.Errorf("clone source instance ID is not available")
+	}
+
+	spec.CloneSource.InstanceID = source.Status.InstanceID
+	spec.CloneSource.InstanceRef = nil
+	return nil
+}
+
+// getInstanceExpiration returns the time at which an instance expires, or nil if it does not expire.
+// the maximum time to live of the inventory takes precedence over the one of the dbaaspolicy. a maximum time to live
+// expiring the instance sooner than it was reported is delayed by a grace period, see policyExpirationTime.
+func (r *DBaaSInstanceReconciler) getInstanceExpiration(ctx context.Context, instance *v1alpha1.DBaaSInstance, spec *v1alpha1.DBaaSInstanceSpec) (*metav1.Time, error) {
+	var expirationTime *metav1.Time
+	if spec.ExpirationTime != nil {
+		expirationTime = spec.ExpirationTime.DeepCopy()
+	} else if spec.TTL != nil {
+		t := metav1.NewTime(instance.CreationTimestamp.Add(spec.TTL.Duration))
+		expirationTime = &t
+	}
+
+	inventory := &v1alpha1.DBaaSInventory{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: spec.InventoryRef.Namespace, Name: spec.InventoryRef.Name}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			// the missing inventory is reported by checkInventory
+			return expirationTime, nil
+		}
+		return nil, err
+	}
+	policy, err := v1alpha1.GetInventoryPolicy(ctx, r.Client, inventory)
+	if err != nil {
+		return nil, err
+	}
+	maxTTL := policy.MaxInstanceTTL
+	if maxTTL != nil {
+		maxExpirationTime := policyExpirationTime(instance, metav1.NewTime(instance.CreationTimestamp.Add(maxTTL.Duration)), time.Now())
+		if expirationTime == nil || maxExpirationTime.Before(expirationTime) {
+			expirationTime = &maxExpirationTime
+		}
+	}
+	return expirationTime, nil
+}
+
+// policyExpirationTime returns the time at which the maximum time to live of the policy expires an instance. when the
+// policy adds or lowers the maximum time to live of existing instances, they would expire before reporting it:
+// the expiration is then delayed to the end of a grace period, unless it was already reported.
+func policyExpirationTime(instance *v1alpha1.DBaaSInstance, maxExpirationTime metav1.Time, now time.Time) metav1.Time {
+	graceTime := now.Add(policyExpirationGracePeriod)
+	if !maxExpirationTime.Time.Before(graceTime) {
+		return maxExpirationTime
+	}
+	reported := instance.Status.ExpirationTime
+	if reported != nil && !maxExpirationTime.Before(reported) {
+		// the expiration was reported, the instance was warned
+		return maxExpirationTime
+	}
+	if reported != nil && reported.Time.Before(graceTime) {
+		// the grace period already started
+		return *reported
+	}
+	return metav1.NewTime(graceTime)
+}
+
+// setInstanceExpiration sets the expiration time and the Expiring condition of an instance, and emits an event
+// when the instance enters its expiration warning period
+func (r *DBaaSInstanceReconciler) setInstanceExpiration(instance *v1alpha1.DBaaSInstance, expirationTime *metav1.Time, previous *metav1.Condition) {
+	instance.Status.ExpirationTime = expirationTime
+	if expirationTime == nil {
+		return
+	}
+	// provider conditions replace the instance conditions, restore the previous transition time
+	if previous != nil {
+		apimeta.SetStatusCondition(&instance.Status.Conditions, *previous)
+	}
+	cond := metav1.Condition{
+		Type:    v1alpha1.DBaaSInstanceExpiringType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.InstanceExpirationScheduled,
+		Message: fmt.Sprintf("Instance expires at %s", expirationTime.UTC().Format(time.RFC3339)),
+	}
+	if !time.Now().Before(expirationTime.Add(-getExpirationWarningPeriod(instance))) {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = v1alpha1.InstanceExpirationImminent
+		if previous == nil || previous.Status != metav1.ConditionTrue {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, v1alpha1.InstanceExpirationImminent, cond.Message)
+		}
+	}
+	apimeta.SetStatusCondition(&instance.Status.Conditions, cond)
+}
+
+// deleteExpiredInstance deletes an expired instance and the connections to it
+func (r *DBaaSInstanceReconciler) deleteExpiredInstance(ctx context.Context, instance *v1alpha1.DBaaSInstance, spec *v1alpha1.DBaaSInstanceSpec) error {
+	connections, err := listInstanceConnections(ctx, r.Client, instance, spec.InventoryRef)
+	if err != nil {
+		return err
+	}
+	for i := range connections {
+		if err := r.Client.Delete(ctx, &connections[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, v1alpha1.InstanceExpired, "Instance expired, deleting the instance and its connections")
+	if err := r.Client.Delete(ctx, instance); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// listInstanceConnections lists the connections referencing an instance, by reference or by instance ID
+func listInstanceConnections(ctx context.Context, c client.Reader, instance *v1alpha1.DBaaSInstance, inventoryRef v1alpha1.NamespacedName) ([]v1alpha1.DBaaSConnection, error) {
+	var connectionList v1alpha1.DBaaSConnectionList
+	if err := c.List(ctx, &connectionList, client.MatchingFields{instanceRefKey: instanceRefIndexValue(instance.Namespace, instance.Name)}); err != nil {
+		return nil, err
+	}
+	connections := connectionList.Items
+	if len(instance.Status.InstanceID) == 0 {
+		return connections, nil
+	}
+	// the connections referencing an instance by ID use the same inventory
+	if len(inventoryRef.Namespace) == 0 {
+		inventoryRef.Namespace = instance.Namespace
+	}
+	if err := c.List(ctx, &connectionList, client.MatchingFields{v1alpha1.InventoryRefKey: v1alpha1.InventoryRefIndexValue(inventoryRef)}); err != nil {
+		return nil, err
+	}
+	for _, connection := range connectionList.Items {
+		if connection.Spec.InstanceRef == nil && isConnectionToInstance(&connection, instance, inventoryRef) {
+			connections = append(connections, connection)
+		}
+	}
+	return connections, nil
+}
+
+// instanceRefIndexValue returns the value of the instanceRefKey index for an instance
+func instanceRefIndexValue(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// isConnectionToInstance checks whether a connection references an instance, by reference or by instance ID
+func isConnectionToInstance(connection *v1alpha1.DBaaSConnection, instance *v1alpha1.DBaaSInstance, inventoryRef v1alpha1.NamespacedName) bool {
+	if ref := connection.Spec.InstanceRef; ref != nil {
+		return ref.Name == instance.Name && ref.Namespace == instance.Namespace
+	}
+	return len(instance.Status.InstanceID) > 0 && connection.Spec.InstanceID == instance.Status.InstanceID &&
+		connection.Spec.InventoryRef == inventoryRef
+}
+
+// getExpirationWarningPeriod returns how long before its expiration an instance reports that it is expiring
+func getExpirationWarningPeriod(instance *v1alpha1.DBaaSInstance) time.Duration {
+	if instance.Spec.ExpirationWarningPeriod != nil {
+		return instance.Spec.ExpirationWarningPeriod.Duration
+	}
+	return defaultExpirationWarningPeriod
+}
+
+// nextExpirationCheck returns the delay until the instance enters its expiration warning period, or until it expires
+func nextExpirationCheck(instance *v1alpha1.DBaaSInstance, expirationTime *metav1.Time) time.Duration {
+	if warning := time.Until(expirationTime.Add(-getExpirationWarningPeriod(instance))); warning > 0 {
+		return warning
+	}
+	return time.Until(expirationTime.Time)
+}
+
+// mergeRequeueAfter requeues a result after the given delay, unless it is already requeued sooner
+func mergeRequeueAfter(result ctrl.Result, after time.Duration) ctrl.Result {
+	if !result.Requeue && (result.RequeueAfter == 0 || result.RequeueAfter > after) {
+		result.RequeueAfter = after
+	}
+	return result
+}
+
+func (r *DBaaSInstanceReconciler) updateInstanceStatus(ctx context.Context, instance *v1alpha1.DBaaSInstance, reason, message string) {
+	logger := ctrl.LoggerFrom(ctx)
+	apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.DBaaSInstanceReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	instance.Status.Phase = v1alpha1.InstancePhaseError
+	recordInstancePhase(instance, reason)
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Instance modified", "DBaaS Instance", instance)
+		} else {
+			logger.Error(err, "Error updating the DBaaS Instance status", "DBaaS Instance", instance)
+		}
+	}
+}
+
 // mergeInstanceStatus: merge the status from DBaaSProviderInstance into the current DBaaSInstance status
 func mergeInstanceStatus(instance *v1alpha1.DBaaSInstance, providerInst *v1alpha1.DBaaSProviderInstance) metav1.Condition {
+	// the phase history, the power state and the approval are kept by the operator, preserve them across merges
+	phaseHistory, powerState, approval := instance.Status.PhaseHistory, instance.Status.PowerState, instance.Status.Approval
+	approvalCond := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceApprovalType)
 	providerInst.Status.DeepCopyInto(&instance.Status)
+	instance.Status.PhaseHistory, instance.Status.PowerState, instance.Status.Approval = phaseHistory, powerState, approval
+	if approvalCond != nil {
+		apimeta.SetStatusCondition(&instance.Status.Conditions, *approvalCond)
+	}
 	if len(instance.Status.Phase) == 0 {
 		instance.Status.Phase = v1alpha1.InstancePhaseUnknown
 	}
+	// providers without a paused phase report a paused instance as ready
+	if powerState == v1alpha1.InstancePowerStatePaused && instance.Status.Phase == v1alpha1.InstancePhaseReady {
+		instance.Status.Phase = v1alpha1.InstancePhasePaused
+	}
 	// Update instance status condition (type: DBaaSInstanceReadyType) based on the provider status
 	specSync := apimeta.FindStatusCondition(providerInst.Status.Conditions, v1alpha1.DBaaSInstanceProviderSyncType)
+	if specSync != nil {
+		recordInstancePhase(instance, specSync.Reason)
+	} else {
+		recordInstancePhase(instance, "")
+	}
 	if specSync != nil && specSync.Status == metav1.ConditionTrue {
 		return metav1.Condition{
 			Type:    v1alpha1.DBaaSInstanceReadyType,
@@ -142,6 +666,23 @@ func mergeInstanceStatus(instance *v1alpha1.DBaaSInstance, providerInst *v1alpha
 	}
 }
 
+// recordInstancePhase appends the current phase to the phase history of the instance if the phase changed
+func recordInstancePhase(instance *v1alpha1.DBaaSInstance, reason string) {
+	history := instance.Status.PhaseHistory
+	if n := len(history); n > 0 && history[n-1].Phase == instance.Status.Phase {
+		return
+	}
+	history = append(history, v1alpha1.DBaaSInstancePhaseTransition{
+		Phase:          instance.Status.Phase,
+		Reason:         reason,
+		TransitionTime: metav1.Now(),
+	})
+	if len(history) > instancePhaseHistoryLimit {
+		history = history[len(history)-instancePhaseHistoryLimit:]
+	}
+	instance.Status.PhaseHistory = history
+}
+
 // Delete implements a handler for the Delete event.
 func (r *DBaaSInstanceReconciler) Delete(e event.DeleteEvent) error {
 
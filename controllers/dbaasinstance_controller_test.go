@@ -17,10 +17,18 @@ limitations under the License.
 package controllers
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 )
@@ -632,3 +640,569 @@ var _ = Describe("DBaaSInstance controller - valid dev namespaces", func() {
 		})
 	})
 })
+
+var _ = Describe("DBaaSInstance controller - namespace policy changes", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Context("after creating a DBaaSInstance in a namespace selected by an inventory tearing down connections", func() {
+		teardown := true
+		otherNS := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "other-instance-revoked",
+				Labels: map[string]string{"dbaas-test": "instance-revoked"},
+			},
+		}
+		createdDBaaSInventory := &v1alpha1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-inventory-instance-revoked",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1alpha1.NamespacedName{
+					Name: testProviderName,
+				},
+				DBaaSInventoryPolicy: v1alpha1.DBaaSInventoryPolicy{
+					ConnectionNsSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"dbaas-test": "instance-revoked"},
+					},
+					TeardownOnInvalidNamespace: &teardown,
+				},
+				DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+					CredentialsRef: &v1alpha1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+			},
+		}
+		providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               "SpecSynced",
+					Status:             metav1.ConditionTrue,
+					Reason:             "SyncOK",
+					LastTransitionTime: metav1.Time{Time: getLastTransitionTimeForTest()},
+				},
+			},
+		}
+		DBaaSInstanceSpec := &v1alpha1.DBaaSInstanceSpec{
+			InventoryRef: v1alpha1.NamespacedName{
+				Name:      createdDBaaSInventory.Name,
+				Namespace: testNamespace,
+			},
+			Name: "test-instance-revoked",
+		}
+		createdDBaaSInstance := &v1alpha1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-revoked",
+				Namespace: otherNS.Name,
+			},
+			Spec: *DBaaSInstanceSpec,
+		}
+		BeforeEach(assertResourceCreationIfNotExists(otherNS))
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		AfterEach(assertResourceDeletion(createdDBaaSInstance))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+
+		It("should invalidate the instance without deleting its provider object when the namespace is relabeled", func() {
+			assertProviderResourceCreated(createdDBaaSInstance, testInstanceKind, DBaaSInstanceSpec)()
+
+			By("removing the selected label from the namespace")
+			ns := &v1.Namespace{}
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(otherNS), ns)).Should(Succeed())
+			ns.Labels = nil
+			Expect(dRec.Update(ctx, ns)).Should(Succeed())
+
+			assertDBaaSResourceStatusUpdated(createdDBaaSInstance, metav1.ConditionFalse, v1alpha1.DBaaSInvalidNamespace)()
+			Consistently(func() error {
+				providerInstance := dRec.createProviderObject(createdDBaaSInstance, testInstanceKind)
+				return dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), providerInstance)
+			}, "2s").Should(Succeed())
+		})
+	})
+})
+
+var _ = Describe("DBaaSInstance controller - instance class", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Describe("reconcile", func() {
+		Context("after creating DBaaSInventory and DBaaSInstanceClass", func() {
+			inventoryRefName := "test-inventory-ref-class"
+			createdDBaaSInventory := &v1alpha1.DBaaSInventory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      inventoryRefName,
+					Namespace: testNamespace,
+				},
+				Spec: v1alpha1.DBaaSOperatorInventorySpec{
+					ProviderRef: v1alpha1.NamespacedName{
+						Name: testProviderName,
+					},
+					DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+						CredentialsRef: &v1alpha1.LocalObjectReference{
+							Name: testSecret.Name,
+						},
+					},
+				},
+			}
+			lastTransitionTime := getLastTransitionTimeForTest()
+			providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
+				Instances: []v1alpha1.Instance{
+					{
+						InstanceID: "testInstanceID",
+						Name:       "testInstance",
+					},
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:               "SpecSynced",
+						Status:             metav1.ConditionTrue,
+						Reason:             "SyncOK",
+						LastTransitionTime: metav1.Time{Time: lastTransitionTime},
+					},
+				},
+			}
+			createdDBaaSInstanceClass := &v1alpha1.DBaaSInstanceClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-dev-small",
+				},
+				Spec: v1alpha1.DBaaSInstanceClassSpec{
+					ProviderRef: v1alpha1.NamespacedName{
+						Name: testProviderName,
+					},
+					InventoryRef: &v1alpha1.NamespacedName{
+						Name:      inventoryRefName,
+						Namespace: testNamespace,
+					},
+					CloudProvider: "aws",
+					CloudRegion:   "class-region",
+					OtherInstanceParams: map[string]string{
+						"testParam":  "class-param",
+						"classParam": "class-param",
+					},
+				},
+			}
+			BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+			BeforeEach(assertResourceCreationIfNotExists(createdDBaaSInstanceClass))
+			AfterEach(assertResourceDeletion(createdDBaaSInstanceClass))
+			AfterEach(assertResourceDeletion(createdDBaaSInventory))
+
+			Context("after creating DBaaSInstance referencing the class", func() {
+				createdDBaaSInstance := &v1alpha1.DBaaSInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-instance-class",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.DBaaSInstanceSpec{
+						InstanceClassName: createdDBaaSInstanceClass.Name,
+						Name:              "test-instance",
+						CloudRegion:       "test-region",
+						OtherInstanceParams: map[string]string{
+							"testParam": "test-param",
+						},
+					},
+				}
+				mergedSpec := &v1alpha1.DBaaSInstanceSpec{
+					InventoryRef: v1alpha1.NamespacedName{
+						Name:      inventoryRefName,
+						Namespace: testNamespace,
+					},
+					Name:          "test-instance",
+					CloudProvider: "aws",
+					CloudRegion:   "test-region",
+					OtherInstanceParams: map[string]string{
+						"testParam":  "test-param",
+						"classParam": "class-param",
+					},
+				}
+				BeforeEach(assertResourceCreation(createdDBaaSInstance))
+				AfterEach(assertResourceDeletion(createdDBaaSInstance))
+
+				It("should create a provider instance with the class presets", assertProviderResourceCreated(createdDBaaSInstance, testInstanceKind, mergedSpec))
+				It("should record the applied instance class", func() {
+					Eventually(func() bool {
+						instance := &v1alpha1.DBaaSInstance{}
+						if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), instance); err != nil {
+							return false
+						}
+						return instance.Status.InstanceClass != nil &&
+							instance.Status.InstanceClass.Name == createdDBaaSInstanceClass.Name &&
+							instance.Status.InstanceClass.Generation == createdDBaaSInstanceClass.Generation
+					}, timeout).Should(BeTrue())
+				})
+			})
+		})
+
+		Context("after creating DBaaSInstance referencing a missing class", func() {
+			createdDBaaSInstance := &v1alpha1.DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-instance-no-class",
+					Namespace: testNamespace,
+				},
+				Spec: v1alpha1.DBaaSInstanceSpec{
+					InstanceClassName: "test-class-no-exist",
+					Name:              "test-instance",
+				},
+			}
+			BeforeEach(assertResourceCreation(createdDBaaSInstance))
+			AfterEach(assertResourceDeletion(createdDBaaSInstance))
+			It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSInstance, metav1.ConditionFalse, v1alpha1.DBaaSInstanceClassNotFound))
+		})
+	})
+})
+
+var _ = Describe("DBaaSInstance phase history", func() {
+	It("should only record phase changes", func() {
+		instance := &v1alpha1.DBaaSInstance{}
+		instance.Status.Phase = v1alpha1.InstancePhasePending
+		recordInstancePhase(instance, "Pending")
+		recordInstancePhase(instance, "Pending")
+		instance.Status.Phase = v1alpha1.InstancePhaseCreating
+		recordInstancePhase(instance, "Creating")
+		Expect(instance.Status.PhaseHistory).Should(HaveLen(2))
+		Expect(instance.Status.PhaseHistory[0].Phase).Should(Equal(v1alpha1.InstancePhasePending))
+		Expect(instance.Status.PhaseHistory[1].Phase).Should(Equal(v1alpha1.InstancePhaseCreating))
+		Expect(instance.Status.PhaseHistory[1].Reason).Should(Equal("Creating"))
+	})
+
+	It("should keep a bounded history", func() {
+		instance := &v1alpha1.DBaaSInstance{}
+		for i := 0; i < instancePhaseHistoryLimit+5; i++ {
+			if i%2 == 0 {
+				instance.Status.Phase = v1alpha1.InstancePhaseUpdating
+			} else {
+				instance.Status.Phase = v1alpha1.InstancePhaseReady
+			}
+			recordInstancePhase(instance, "")
+		}
+		Expect(instance.Status.PhaseHistory).Should(HaveLen(instancePhaseHistoryLimit))
+		Expect(instance.Status.PhaseHistory[instancePhaseHistoryLimit-1].Phase).Should(Equal(instance.Status.Phase))
+	})
+
+	It("should preserve the history across provider status merges", func() {
+		instance := &v1alpha1.DBaaSInstance{}
+		instance.Status.Phase = v1alpha1.InstancePhaseCreating
+		recordInstancePhase(instance, "")
+		providerInstance := &v1alpha1.DBaaSProviderInstance{
+			Status: v1alpha1.DBaaSInstanceStatus{
+				Phase: v1alpha1.InstancePhaseReady,
+				Conditions: []metav1.Condition{
+					{
+						Type:   v1alpha1.DBaaSInstanceProviderSyncType,
+						Status: metav1.ConditionTrue,
+						Reason: "SyncOK",
+					},
+				},
+			},
+		}
+		mergeInstanceStatus(instance, providerInstance)
+		Expect(instance.Status.PhaseHistory).Should(HaveLen(2))
+		Expect(instance.Status.PhaseHistory[0].Phase).Should(Equal(v1alpha1.InstancePhaseCreating))
+		Expect(instance.Status.PhaseHistory[1].Phase).Should(Equal(v1alpha1.InstancePhaseReady))
+		Expect(instance.Status.PhaseHistory[1].Reason).Should(Equal("SyncOK"))
+	})
+})
+
+var _ = Describe("DBaaSInstance expiration", func() {
+	It("should report a scheduled expiration", func() {
+		recorder := record.NewFakeRecorder(1)
+		r := &DBaaSInstanceReconciler{Recorder: recorder}
+		instance := &v1alpha1.DBaaSInstance{}
+		expirationTime := metav1.NewTime(time.Now().Add(2 * time.Hour))
+		r.setInstanceExpiration(instance, &expirationTime, nil)
+		Expect(instance.Status.ExpirationTime).Should(Equal(&expirationTime))
+		cond := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceExpiringType)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).Should(Equal(v1alpha1.InstanceExpirationScheduled))
+		Expect(recorder.Events).Should(BeEmpty())
+		Expect(nextExpirationCheck(instance, &expirationTime)).Should(BeNumerically("~", time.Hour, time.Minute))
+	})
+
+	It("should warn once within the warning period", func() {
+		recorder := record.NewFakeRecorder(2)
+		r := &DBaaSInstanceReconciler{Recorder: recorder}
+		instance := &v1alpha1.DBaaSInstance{}
+		instance.Spec.ExpirationWarningPeriod = &metav1.Duration{Duration: 3 * time.Hour}
+		expirationTime := metav1.NewTime(time.Now().Add(2 * time.Hour))
+		r.setInstanceExpiration(instance, &expirationTime, nil)
+		cond := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceExpiringType)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).Should(Equal(v1alpha1.InstanceExpirationImminent))
+		Expect(recorder.Events).Should(HaveLen(1))
+
+		r.setInstanceExpiration(instance, &expirationTime, cond.DeepCopy())
+		Expect(recorder.Events).Should(HaveLen(1))
+		Expect(nextExpirationCheck(instance, &expirationTime)).Should(BeNumerically("~", 2*time.Hour, time.Minute))
+	})
+
+	It("should give a grace period before a policy time to live expires an instance", func() {
+		instance := &v1alpha1.DBaaSInstance{}
+		now := time.Now()
+		maxExpirationTime := metav1.NewTime(now.Add(-time.Hour))
+		graceTime := policyExpirationTime(instance, maxExpirationTime, now)
+		Expect(graceTime.Time).Should(Equal(now.Add(policyExpirationGracePeriod)))
+
+		instance.Status.ExpirationTime = &graceTime
+		Expect(policyExpirationTime(instance, maxExpirationTime, now.Add(time.Minute))).Should(Equal(graceTime))
+
+		laterExpirationTime := metav1.NewTime(now.Add(2 * policyExpirationGracePeriod))
+		Expect(policyExpirationTime(instance, laterExpirationTime, now)).Should(Equal(laterExpirationTime))
+
+		reportedTime := metav1.NewTime(now.Add(-time.Minute))
+		instance.Status.ExpirationTime = &reportedTime
+		Expect(policyExpirationTime(instance, reportedTime, now)).Should(Equal(reportedTime))
+	})
+
+	Context("after creating an expired DBaaSInstance", func() {
+		expirationTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		createdDBaaSInstance := &v1alpha1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-expired",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSInstanceSpec{
+				InventoryRef: v1alpha1.NamespacedName{
+					Name:      "test-inventory-expired",
+					Namespace: testNamespace,
+				},
+				Name:           "test-instance-expired",
+				ExpirationTime: &expirationTime,
+			},
+		}
+		createdDBaaSConnection := &v1alpha1.DBaaSConnection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-connection-expired",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSConnectionSpec{
+				InventoryRef: createdDBaaSInstance.Spec.InventoryRef,
+				InstanceRef: &v1alpha1.NamespacedName{
+					Name:      createdDBaaSInstance.Name,
+					Namespace: testNamespace,
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(createdDBaaSConnection))
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+
+		It("should delete the instance and its connections", func() {
+			Eventually(func() bool {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), &v1alpha1.DBaaSInstance{})
+				return errors.IsNotFound(err)
+			}, timeout).Should(BeTrue())
+			Eventually(func() bool {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSConnection), &v1alpha1.DBaaSConnection{})
+				return errors.IsNotFound(err)
+			}, timeout).Should(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("DBaaSInstance approval", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Context("after creating a DBaaSInstance against an inventory requiring approval", func() {
+		requireApproval := true
+		createdDBaaSInventory := &v1alpha1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-inventory-approval",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1alpha1.NamespacedName{
+					Name: testProviderName,
+				},
+				DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+					CredentialsRef: &v1alpha1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+				DBaaSInventoryPolicy: v1alpha1.DBaaSInventoryPolicy{
+					RequireInstanceApproval: &requireApproval,
+				},
+			},
+		}
+		providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               "SpecSynced",
+					Status:             metav1.ConditionTrue,
+					Reason:             "SyncOK",
+					LastTransitionTime: metav1.Time{Time: getLastTransitionTimeForTest()},
+				},
+			},
+		}
+		DBaaSInstanceSpec := &v1alpha1.DBaaSInstanceSpec{
+			InventoryRef: v1alpha1.NamespacedName{
+				Name:      createdDBaaSInventory.Name,
+				Namespace: testNamespace,
+			},
+			Name: "test-instance-approval",
+		}
+		createdDBaaSInstance := &v1alpha1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-approval",
+				Namespace: testNamespace,
+			},
+			Spec: *DBaaSInstanceSpec,
+		}
+		approval := &v1alpha1.DBaaSInstanceApproval{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-approval",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSInstanceApprovalSpec{
+				InstanceRef: v1alpha1.LocalObjectReference{Name: createdDBaaSInstance.Name},
+				Decision:    v1alpha1.InstanceApprovalApproved,
+				Approver:    "test-approver",
+			},
+		}
+		approverRole := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-approver",
+				Namespace: testNamespace,
+			},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{v1alpha1.GroupVersion.Group},
+				Resources: []string{"dbaasinstances"},
+				Verbs:     []string{v1alpha1.ApproveVerb},
+			}},
+		}
+		approverRoleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-approver",
+				Namespace: testNamespace,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     approverRole.Name,
+			},
+			Subjects: []rbacv1.Subject{{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.UserKind,
+				Name:     "test-approver",
+			}},
+		}
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		AfterEach(assertResourceDeletion(approval))
+		AfterEach(assertResourceDeletion(createdDBaaSInstance))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+
+		It("should not provision an instance approved by an unauthorized approver", func() {
+			assertDBaaSResourceStatusUpdated(createdDBaaSInstance, metav1.ConditionFalse, v1alpha1.DBaaSInstanceAwaitingApproval)()
+
+			By("approving the instance without the approve permission")
+			assertResourceCreation(approval)()
+			Eventually(func() (*metav1.Condition, error) {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance)
+				return apimeta.FindStatusCondition(createdDBaaSInstance.Status.Conditions, v1alpha1.DBaaSInstanceApprovalType), err
+			}, timeout).Should(And(
+				Not(BeNil()),
+				HaveField("Status", metav1.ConditionTrue),
+				HaveField("Reason", v1alpha1.InstanceApproverUnauthorized),
+			))
+			Expect(createdDBaaSInstance.Status.Approval).Should(BeNil())
+			Expect(createdDBaaSInstance.Status.Phase).Should(Equal(v1alpha1.InstancePhasePending))
+		})
+
+		It("should await approval before creating the provider instance", func() {
+			assertDBaaSResourceStatusUpdated(createdDBaaSInstance, metav1.ConditionFalse, v1alpha1.DBaaSInstanceAwaitingApproval)()
+			Expect(createdDBaaSInstance.Status.Phase).Should(Equal(v1alpha1.InstancePhasePending))
+			cond := apimeta.FindStatusCondition(createdDBaaSInstance.Status.Conditions, v1alpha1.DBaaSInstanceApprovalType)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+
+			By("approving the instance")
+			assertResourceCreation(approverRole)()
+			assertResourceCreation(approverRoleBinding)()
+			defer assertResourceDeletion(approverRoleBinding)()
+			defer assertResourceDeletion(approverRole)()
+			assertResourceCreation(approval)()
+			assertProviderResourceCreated(createdDBaaSInstance, testInstanceKind, DBaaSInstanceSpec)()
+			Eventually(func() (*v1alpha1.DBaaSInstanceApprovalRecord, error) {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance)
+				return createdDBaaSInstance.Status.Approval, err
+			}, timeout).Should(And(
+				Not(BeNil()),
+				HaveField("Decision", v1alpha1.InstanceApprovalApproved),
+				HaveField("Approver", "test-approver"),
+			))
+		})
+	})
+
+	It("should keep the approval across provider status merges", func() {
+		instance := &v1alpha1.DBaaSInstance{}
+		instance.Status.Approval = &v1alpha1.DBaaSInstanceApprovalRecord{Name: "test", Decision: v1alpha1.InstanceApprovalApproved}
+		apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:   v1alpha1.DBaaSInstanceApprovalType,
+			Status: metav1.ConditionFalse,
+			Reason: string(v1alpha1.InstanceApprovalApproved),
+		})
+		mergeInstanceStatus(instance, &v1alpha1.DBaaSProviderInstance{})
+		Expect(instance.Status.Approval).ShouldNot(BeNil())
+		Expect(apimeta.IsStatusConditionFalse(instance.Status.Conditions, v1alpha1.DBaaSInstanceApprovalType)).Should(BeTrue())
+	})
+})
+
+var _ = Describe("DBaaSInstance power state", func() {
+	// Saturday
+	now := time.Date(2022, time.May, 7, 10, 30, 0, 0, time.UTC)
+
+	It("should use the desired power state without schedules", func() {
+		spec := &v1alpha1.DBaaSInstanceSpec{PowerState: v1alpha1.InstancePowerStatePaused}
+		powerState, next, err := getInstancePowerState(spec, now)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(powerState).Should(Equal(v1alpha1.InstancePowerStatePaused))
+		Expect(next).Should(BeNil())
+	})
+
+	It("should use the most recent schedule activation", func() {
+		spec := &v1alpha1.DBaaSInstanceSpec{
+			PowerState: v1alpha1.InstancePowerStateRunning,
+			PowerSchedules: []v1alpha1.DBaaSInstancePowerSchedule{
+				{Schedule: "0 20 * * 1-5", PowerState: v1alpha1.InstancePowerStatePaused},
+				{Schedule: "0 8 * * 1-5", PowerState: v1alpha1.InstancePowerStateRunning},
+			},
+		}
+		powerState, next, err := getInstancePowerState(spec, now)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(powerState).Should(Equal(v1alpha1.InstancePowerStatePaused))
+		Expect(next).ShouldNot(BeNil())
+		Expect(*next).Should(Equal(time.Date(2022, time.May, 9, 8, 0, 0, 0, time.UTC)))
+	})
+
+	It("should reject invalid schedules", func() {
+		spec := &v1alpha1.DBaaSInstanceSpec{
+			PowerSchedules: []v1alpha1.DBaaSInstancePowerSchedule{
+				{Schedule: "0 20 * *", PowerState: v1alpha1.InstancePowerStatePaused},
+			},
+		}
+		_, _, err := getInstancePowerState(spec, now)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should report a paused instance as paused", func() {
+		instance := &v1alpha1.DBaaSInstance{}
+		instance.Status.PowerState = v1alpha1.InstancePowerStatePaused
+		providerInstance := &v1alpha1.DBaaSProviderInstance{
+			Status: v1alpha1.DBaaSInstanceStatus{
+				Phase: v1alpha1.InstancePhaseReady,
+			},
+		}
+		mergeInstanceStatus(instance, providerInstance)
+		Expect(instance.Status.Phase).Should(Equal(v1alpha1.InstancePhasePaused))
+		Expect(instance.Status.PowerState).Should(Equal(v1alpha1.InstancePowerStatePaused))
+	})
+})
@@ -18,26 +18,52 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// inventoryFinalizer enforces the deletion policy of an inventory on its dependents
+const inventoryFinalizer = "dbaas.redhat.com/inventory-dependents"
+
+const (
+	// maxDependentNamespaces caps the namespaces listed in the dependents summary of an inventory
+	maxDependentNamespaces = 50
+	// maxDependentNames caps the connection and instance names listed per namespace in the dependents summary
+	maxDependentNames = 10
+)
+
 // DBaaSInventoryReconciler reconciles a DBaaSInventory object
 type DBaaSInventoryReconciler struct {
 	*DBaaSReconciler
+	// CredentialsSourceDir is the directory that file credentials sources are relative to
+	CredentialsSourceDir string
+	// CredentialsSourceAllowedHosts are the hosts that HTTP credentials sources may read from
+	CredentialsSourceAllowedHosts []string
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -58,13 +84,35 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	if !inventory.DeletionTimestamp.IsZero() {
+		return r.finalizeInventory(ctx, &inventory)
+	}
+	if !controllerutil.ContainsFinalizer(&inventory, inventoryFinalizer) {
+		controllerutil.AddFinalizer(&inventory, inventoryFinalizer)
+		if err := r.Update(ctx, &inventory); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Inventory resource modified, retry adding the finalizer", "DBaaS Inventory", inventory)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error adding the finalizer to the DBaaS Inventory", "DBaaS Inventory", inventory)
+			return ctrl.Result{}, err
+		}
+	}
+
+	connectionList, instanceList, err := r.listInventoryDependents(ctx, &inventory)
+	if err != nil {
+		logger.Error(err, "Error listing the dependents of the DBaaS Inventory")
+		return ctrl.Result{}, err
+	}
+	inventory.Status.Dependents = getInventoryDependents(connectionList, instanceList)
+
 	policyList, err := r.policyListByNS(ctx, req.Namespace)
 	if err != nil {
 		logger.Error(err, "unable to list policies")
 		return ctrl.Result{}, err
 	}
-	activePolicy := getActivePolicy(policyList)
-	if activePolicy == nil {
+	effectivePolicy := v1alpha1.EffectivePolicy(policyList.Items)
+	if effectivePolicy == nil {
 		logger.Info("No DBaaSPolicy found for the target namespace", "Namespace", req.Namespace)
 		cond := metav1.Condition{
 			Type:    v1alpha1.DBaaSInventoryReadyType,
@@ -83,13 +131,49 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 		return ctrl.Result{}, nil
 	}
+	clusterPolicy, err := v1alpha1.GetClusterPolicy(ctx, r.Client)
+	if err != nil {
+		logger.Error(err, "Error fetching the Cluster DBaaS Policy")
+		return ctrl.Result{}, err
+	}
+	policy := v1alpha1.InventoryPolicy(&inventory, effectivePolicy, clusterPolicy)
+	// inventories of providers denied after their creation are reported, not deleted
+	setPolicyViolationCondition(&inventory, policy)
+	allowedNamespaces, err := r.listAllowedNamespaces(ctx, inventory.Namespace, policy, clusterPolicy)
+	if err != nil {
+		logger.Error(err, "Error listing the namespaces allowed by the DBaaS Inventory policy", "DBaaS Inventory", inventory)
+		return ctrl.Result{}, err
+	}
+	inventory.Status.AllowedNamespaces = v1alpha1.NewAllowedNamespaces(allowedNamespaces, policy)
+
+	if err := r.syncCredentials(ctx, &inventory); err != nil {
+		logger.Error(err, "Error reading the credentials source of the DBaaS Inventory", "DBaaS Inventory", inventory)
+		apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.DBaaSInventoryCredentialsType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.CredentialsSourceError,
+			Message: err.Error(),
+		})
+		if errCond := r.Client.Status().Update(ctx, &inventory); errCond != nil && !errors.IsConflict(errCond) {
+			logger.Error(errCond, "Error updating the DBaaS Inventory resource status", "DBaaS Inventory", inventory)
+		}
+		return ctrl.Result{}, err
+	}
 
-	if err := r.checkCredsRefLabel(ctx, inventory); err != nil {
+	credentialsResourceVersion, err := r.checkCredsRefLabel(ctx, inventory)
+	if err != nil {
 		if errors.IsConflict(err) {
 			return ctrl.Result{Requeue: true}, nil
 		}
 		return ctrl.Result{}, err
 	}
+	// passed to the provider inventory, so that providers resync when the credentials change
+	inventory.Status.CredentialsResourceVersion = credentialsResourceVersion
+	_, refresh := inventory.GetAnnotations()[v1alpha1.RefreshAnnotation]
+	if refresh {
+		now := metav1.Now()
+		inventory.Status.LastRefreshTime = &now
+	}
 
 	defer func() {
 		SetInventoryMetrics(inventory, execution)
@@ -99,7 +183,8 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	//
 	// Provider Inventory
 	//
-	return r.reconcileProviderResource(ctx,
+	var providerInventory *v1alpha1.DBaaSProviderInventory
+	result, err := r.reconcileProviderResource(ctx,
 		inventory.Spec.ProviderRef.Name,
 		&inventory,
 		func(provider *v1alpha1.DBaaSProvider) string {
@@ -112,8 +197,8 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return &v1alpha1.DBaaSProviderInventory{}
 		},
 		func(i interface{}) metav1.Condition {
-			providerInv := i.(*v1alpha1.DBaaSProviderInventory)
-			return mergeInventoryStatus(&inventory, providerInv)
+			providerInventory = i.(*v1alpha1.DBaaSProviderInventory)
+			return mergeInventoryStatus(&inventory, providerInventory)
 		},
 		func() *[]metav1.Condition {
 			return &inventory.Status.Conditions
@@ -121,24 +206,399 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		v1alpha1.DBaaSInventoryReadyType,
 		logger,
 	)
+	if err == nil && providerInventory != nil {
+		if err := r.syncDiscoveredInstances(ctx, &inventory, getExposedInstances(&inventory, providerInventory.Status.Instances)); err != nil {
+			if errors.IsConflict(err) || errors.IsAlreadyExists(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error syncing the DBaaS Discovered Instances of the DBaaS Inventory", "DBaaS Inventory", inventory)
+			return ctrl.Result{}, err
+		}
+	}
+	if err == nil && inventory.Spec.CredentialsSource != nil {
+		// read the credentials source again to refresh the Secret
+		result = mergeRequeueAfter(result, nextCredentialsFetch(&inventory, time.Now()))
+	}
+	if err == nil && refresh && !result.Requeue {
+		// the refresh request was passed to the provider inventory, clear it
+		patch := client.MergeFrom(inventory.DeepCopy())
+		delete(inventory.Annotations, v1alpha1.RefreshAnnotation)
+		if err := r.Patch(ctx, &inventory, patch); err != nil {
+			if errors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error removing the refresh annotation from the DBaaS Inventory", "DBaaS Inventory", inventory)
+			return ctrl.Result{}, err
+		}
+		logger.Info("DBaaS Inventory refresh requested", "DBaaS Inventory", inventory.Name)
+	}
+	return result, err
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSInventoryReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
-	return ctrl.NewControllerManagedBy(mgr).
+	// index connections and instances by the inventory they resolve to
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DBaaSConnection{}, v1alpha1.InventoryRefKey, v1alpha1.InventoryRefIndexer(mgr.GetClient())); err != nil {
+		return nil, err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DBaaSInstance{}, v1alpha1.InventoryRefKey, v1alpha1.InventoryRefIndexer(mgr.GetClient())); err != nil {
+		return nil, err
+	}
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBaaSInventory{}).
 		Watches(&source.Kind{Type: &v1alpha1.DBaaSInventory{}}, &EventHandlerWithDelete{Controller: r}).
+		Owns(&v1alpha1.DBaaSDiscoveredInstance{}).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSConnection{}}, handler.EnqueueRequestsFromMapFunc(r.dependentMapFn)).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.dependentMapFn)).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.policyMapFn)).
+		Watches(&source.Kind{Type: &v1alpha1.ClusterDBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.policyMapFn))
+	builder = watchNamespaces(builder, r.policyMapFn)
+	// secrets are not cached by the manager, only watch the credentials secrets labelled by checkCredsRefLabel
+	for _, labelKey := range []string{v1alpha1.TypeLabelKey, v1alpha1.TypeLabelKeyMongo} {
+		secretCache, err := cache.New(mgr.GetConfig(), cache.Options{
+			Scheme: mgr.GetScheme(),
+			Mapper: mgr.GetRESTMapper(),
+			SelectorsByObject: cache.SelectorsByObject{
+				&corev1.Secret{}: {Label: labels.SelectorFromSet(labels.Set{labelKey: v1alpha1.TypeLabelValue})},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := mgr.Add(secretCache); err != nil {
+			return nil, err
+		}
+		builder = builder.Watches(source.NewKindWithCache(&corev1.Secret{}, secretCache), handler.EnqueueRequestsFromMapFunc(r.secretMapFn))
+	}
+	return builder.
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
 		Build(r)
 }
 
+// dependentMapFn maps a DBaaSConnection or DBaaSInstance to the DBaaSInventory it references,
+// so that the dependents summary is kept current and inventories blocked by their dependents are finalized
+// once the dependents are gone
+func (r *DBaaSInventoryReconciler) dependentMapFn(o client.Object) []reconcile.Request {
+	inventoryRef, err := v1alpha1.ResolveInventoryRef(context.Background(), r.Client, o)
+	if err != nil {
+		ctrl.Log.WithName("DBaaSInventoryReconciler").Error(err, "unable to resolve the inventory reference", "Namespace", o.GetNamespace(), "Name", o.GetName())
+		return nil
+	}
+	if inventoryRef == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: inventoryRef.Name, Namespace: inventoryRef.Namespace}}}
+}
+
+// policyMapFn maps a DBaaSPolicy to the DBaaSInventories of its namespace, and the ClusterDBaaSPolicy or a created,
+// deleted or relabeled namespace to all the DBaaSInventories
+func (r *DBaaSInventoryReconciler) policyMapFn(o client.Object) []reconcile.Request {
+	var inventoryList v1alpha1.DBaaSInventoryList
+	if err := r.List(context.Background(), &inventoryList, client.InNamespace(o.GetNamespace())); err != nil {
+		ctrl.Log.WithName("DBaaSInventoryReconciler").Error(err, "unable to list inventories", "Namespace", o.GetNamespace())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(inventoryList.Items))
+	for i := range inventoryList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&inventoryList.Items[i])})
+	}
+	return requests
+}
+
+// setPolicyViolationCondition sets the PolicyViolation condition of an inventory whose provider is not allowed by its policy,
+// and removes it once the provider is allowed
+func setPolicyViolationCondition(inventory *v1alpha1.DBaaSInventory, policy *v1alpha1.DBaaSInventoryPolicy) {
+	if policy.AllowsProvider(inventory.Spec.ProviderRef.Name) {
+		apimeta.RemoveStatusCondition(&inventory.Status.Conditions, v1alpha1.DBaaSInventoryPolicyViolation)
+		return
+	}
+	apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.DBaaSInventoryPolicyViolation,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.DBaaSProviderNotAllowed,
+		Message: v1alpha1.MsgProviderNotAllowed,
+	})
+}
+
+// finalizeInventory enforces the deletion policy of a deleted inventory on its dependents, then removes the finalizer
+func (r *DBaaSInventoryReconciler) finalizeInventory(ctx context.Context, inventory *v1alpha1.DBaaSInventory) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if !controllerutil.ContainsFinalizer(inventory, inventoryFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	connectionList, instanceList, err := r.listInventoryDependents(ctx, inventory)
+	if err != nil {
+		logger.Error(err, "Error listing the dependents of the DBaaS Inventory")
+		return ctrl.Result{}, err
+	}
+	dependents := make([]client.Object, 0, len(connectionList.Items)+len(instanceList.Items))
+	for i := range connectionList.Items {
+		dependents = append(dependents, &connectionList.Items[i])
+	}
+	for i := range instanceList.Items {
+		dependents = append(dependents, &instanceList.Items[i])
+	}
+
+	switch inventory.Spec.DeletionPolicy {
+	case v1alpha1.InventoryDeletionPolicyBlock:
+		if len(dependents) > 0 {
+			logger.Info("DBaaS Inventory deletion blocked by its dependents", "Dependents", len(dependents))
+			apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+				Type:    v1alpha1.DBaaSInventoryReadyType,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.DBaaSInventoryHasDependents,
+				Message: v1alpha1.MsgInventoryHasDependents,
+			})
+			if err := r.Client.Status().Update(ctx, inventory); err != nil {
+				if errors.IsConflict(err) {
+					return ctrl.Result{Requeue: true}, nil
+				}
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+	case v1alpha1.InventoryDeletionPolicyCascade:
+		for _, dependent := range dependents {
+			if err := r.Client.Delete(ctx, dependent); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Error deleting a dependent of the DBaaS Inventory", "Dependent", client.ObjectKeyFromObject(dependent))
+				return ctrl.Result{}, err
+			}
+		}
+	default:
+		for _, dependent := range dependents {
+			if err := r.markInventoryDeleted(ctx, dependent); err != nil {
+				if errors.IsConflict(err) {
+					return ctrl.Result{Requeue: true}, nil
+				}
+				logger.Error(err, "Error marking a dependent of the DBaaS Inventory", "Dependent", client.ObjectKeyFromObject(dependent))
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	controllerutil.RemoveFinalizer(inventory, inventoryFinalizer)
+	if err := r.Update(ctx, inventory); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error removing the finalizer from the DBaaS Inventory")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	logger.Info("DBaaS Inventory finalized", "Deletion Policy", inventory.Spec.DeletionPolicy, "Dependents", len(dependents))
+	return ctrl.Result{}, nil
+}
+
+// syncCredentials reads the credentials source of an inventory and records its version. the credentials of an external
+// source are materialized in the Secret named by the credentials reference of the inventory, the source is read at most
+// once per refresh interval.
+func (r *DBaaSInventoryReconciler) syncCredentials(ctx context.Context, inventory *v1alpha1.DBaaSInventory) error {
+	if inventory.Spec.CredentialsSource != nil && nextCredentialsFetch(inventory, time.Now()) > 0 {
+		return nil
+	}
+	source, err := r.getCredentialsSource(ctx, inventory)
+	if err != nil || source == nil {
+		return err
+	}
+	data, version, err := source.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if inventory.Spec.CredentialsSource != nil {
+		if inventory.Spec.CredentialsRef == nil {
+			return fmt.Errorf("credentials source of inventory %s requires a credentials reference", inventory.Name)
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventory.Spec.CredentialsRef.Name,
+				Namespace: inventory.Namespace,
+			},
+		}
+		result, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+			secret.Data = data
+			return ctrl.SetControllerReference(inventory, secret, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+		if result != controllerutil.OperationResultNone || version != inventory.Status.CredentialsSourceVersion {
+			ctrl.LoggerFrom(ctx).Info("DBaaS Inventory credentials Secret synced", "Secret", secret.Name, "Version", version, "result", result)
+		}
+		now := metav1.Now()
+		inventory.Status.LastCredentialsFetchTime = &now
+	}
+	inventory.Status.CredentialsSourceVersion = version
+	return nil
+}
+
+// syncDiscoveredInstances creates or updates a DBaaSDiscoveredInstance for each instance exposed by an inventory,
+// and deletes those of the instances no longer exposed
+func (r *DBaaSInventoryReconciler) syncDiscoveredInstances(ctx context.Context, inventory *v1alpha1.DBaaSInventory, instances []v1alpha1.Instance) error {
+	logger := ctrl.LoggerFrom(ctx)
+	var discoveredList v1alpha1.DBaaSDiscoveredInstanceList
+	if err := r.List(ctx, &discoveredList, client.InNamespace(inventory.Namespace), client.MatchingLabels{v1alpha1.InventoryLabelKey: inventory.Name}); err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(instances))
+	for i := range instances {
+		instance := instances[i]
+		discovered := &v1alpha1.DBaaSDiscoveredInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1alpha1.DiscoveredInstanceName(inventory.Name, instance.InstanceID),
+				Namespace: inventory.Namespace,
+			},
+		}
+		names[discovered.Name] = true
+		result, err := controllerutil.CreateOrUpdate(ctx, r.Client, discovered, func() error {
+			labels := discovered.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[v1alpha1.ProviderLabelKey] = inventory.Spec.ProviderRef.Name
+			labels[v1alpha1.InventoryLabelKey] = inventory.Name
+			discovered.SetLabels(labels)
+			discovered.Spec = v1alpha1.DBaaSDiscoveredInstanceSpec{
+				InventoryRef: v1alpha1.NamespacedName{Name: inventory.Name, Namespace: inventory.Namespace},
+				ProviderRef:  inventory.Spec.ProviderRef,
+				Instance:     *instance.DeepCopy(),
+			}
+			return ctrl.SetControllerReference(inventory, discovered, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+		if result != controllerutil.OperationResultNone {
+			logger.V(1).Info("DBaaS Discovered Instance synced", "DBaaS Discovered Instance", discovered.Name, "result", result)
+		}
+	}
+	for i := range discoveredList.Items {
+		if names[discoveredList.Items[i].Name] {
+			continue
+		}
+		if err := r.Client.Delete(ctx, &discoveredList.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		logger.V(1).Info("DBaaS Discovered Instance deleted", "DBaaS Discovered Instance", discoveredList.Items[i].Name)
+	}
+	return nil
+}
+
+// getInventoryDependents summarizes the dependents of an inventory by consuming namespace, in namespace and name order
+func getInventoryDependents(connectionList *v1alpha1.DBaaSConnectionList, instanceList *v1alpha1.DBaaSInstanceList) *v1alpha1.DBaaSInventoryDependents {
+	dependents := &v1alpha1.DBaaSInventoryDependents{
+		ConnectionCount: int32(len(connectionList.Items)),
+		InstanceCount:   int32(len(instanceList.Items)),
+	}
+	byNamespace := map[string]*v1alpha1.DBaaSInventoryNamespaceDependents{}
+	getNamespace := func(namespace string) *v1alpha1.DBaaSInventoryNamespaceDependents {
+		nsDependents, ok := byNamespace[namespace]
+		if !ok {
+			nsDependents = &v1alpha1.DBaaSInventoryNamespaceDependents{Namespace: namespace}
+			byNamespace[namespace] = nsDependents
+		}
+		return nsDependents
+	}
+	for _, connection := range connectionList.Items {
+		nsDependents := getNamespace(connection.Namespace)
+		nsDependents.ConnectionCount++
+		nsDependents.Connections = append(nsDependents.Connections, connection.Name)
+	}
+	for _, instance := range instanceList.Items {
+		nsDependents := getNamespace(instance.Namespace)
+		nsDependents.InstanceCount++
+		nsDependents.Instances = append(nsDependents.Instances, instance.Name)
+	}
+	dependents.NamespaceCount = int32(len(byNamespace))
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for namespace := range byNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	if len(namespaces) > maxDependentNamespaces {
+		namespaces = namespaces[:maxDependentNamespaces]
+	}
+	for _, namespace := range namespaces {
+		nsDependents := byNamespace[namespace]
+		nsDependents.Connections = capDependentNames(nsDependents.Connections)
+		nsDependents.Instances = capDependentNames(nsDependents.Instances)
+		dependents.Namespaces = append(dependents.Namespaces, *nsDependents)
+	}
+	return dependents
+}
+
+// capDependentNames sorts the dependent names and keeps the first maxDependentNames
+func capDependentNames(names []string) []string {
+	sort.Strings(names)
+	if len(names) > maxDependentNames {
+		return names[:maxDependentNames]
+	}
+	return names
+}
+
+// markInventoryDeleted sets the InventoryDeleted condition on an orphaned DBaaSConnection or DBaaSInstance
+func (r *DBaaSInventoryReconciler) markInventoryDeleted(ctx context.Context, dependent client.Object) error {
+	cond := metav1.Condition{
+		Type:    v1alpha1.DBaaSInventoryDeletedType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.DBaaSInventoryNotFound,
+		Message: v1alpha1.MsgInventoryDeleted,
+	}
+	switch v := dependent.(type) {
+	case *v1alpha1.DBaaSConnection:
+		apimeta.SetStatusCondition(&v.Status.Conditions, cond)
+	case *v1alpha1.DBaaSInstance:
+		apimeta.SetStatusCondition(&v.Status.Conditions, cond)
+	}
+	return client.IgnoreNotFound(r.Client.Status().Update(ctx, dependent))
+}
+
+// secretMapFn maps a credentials Secret to the DBaaSInventories referencing it
+func (r *DBaaSInventoryReconciler) secretMapFn(o client.Object) []reconcile.Request {
+	var inventoryList v1alpha1.DBaaSInventoryList
+	if err := r.List(context.Background(), &inventoryList, client.InNamespace(o.GetNamespace())); err != nil {
+		ctrl.Log.WithName("DBaaSInventoryReconciler").Error(err, "Error listing DBaaS Inventories for Secret", "Secret", client.ObjectKeyFromObject(o))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range inventoryList.Items {
+		credentialsRef := inventoryList.Items[i].Spec.CredentialsRef
+		if credentialsRef != nil && credentialsRef.Name == o.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&inventoryList.Items[i])})
+		}
+	}
+	return requests
+}
+
 // mergeInventoryStatus: merge the status from DBaaSProviderInventory into the current DBaaSInventory status
 func mergeInventoryStatus(inv *v1alpha1.DBaaSInventory, providerInv *v1alpha1.DBaaSProviderInventory) metav1.Condition {
+	// the credentials version and sync times are kept by the operator, preserve them across merges
+	credentialsResourceVersion, lastSyncTime, lastRefreshTime := inv.Status.CredentialsResourceVersion, inv.Status.LastSyncTime, inv.Status.LastRefreshTime
+	credentialsSourceVersion, lastCredentialsFetchTime, dependents := inv.Status.CredentialsSourceVersion, inv.Status.LastCredentialsFetchTime, inv.Status.Dependents
+	prevSynced := apimeta.FindStatusCondition(inv.Status.Conditions, v1alpha1.DBaaSInventorySyncedType)
+	prevCredentials := apimeta.FindStatusCondition(inv.Status.Conditions, v1alpha1.DBaaSInventoryCredentialsType)
+	prevViolation := apimeta.FindStatusCondition(inv.Status.Conditions, v1alpha1.DBaaSInventoryPolicyViolation)
+	providerCredentials := apimeta.FindStatusCondition(providerInv.Status.Conditions, v1alpha1.DBaaSInventoryCredentialsType)
 	providerInv.Status.DeepCopyInto(&inv.Status)
+	// restore the previous transition times of the conditions set by the operator
+	for _, cond := range []*metav1.Condition{prevSynced, prevCredentials, prevViolation} {
+		if cond != nil && (cond.Type != v1alpha1.DBaaSInventoryCredentialsType || providerCredentials == nil) {
+			apimeta.SetStatusCondition(&inv.Status.Conditions, *cond)
+		}
+	}
+	inv.Status.CredentialsResourceVersion, inv.Status.LastSyncTime, inv.Status.LastRefreshTime = credentialsResourceVersion, lastSyncTime, lastRefreshTime
+	inv.Status.CredentialsSourceVersion, inv.Status.LastCredentialsFetchTime, inv.Status.Dependents = credentialsSourceVersion, lastCredentialsFetchTime, dependents
+	inv.Status.ObservedInstanceCount = int32(len(inv.Status.Instances))
+	inv.Status.Instances = getExposedInstances(inv, inv.Status.Instances)
+	// the instances are listed as DBaaSDiscoveredInstances
+	if inv.Spec.OmitStatusInstances {
+		inv.Status.Instances = nil
+	}
 	// Update inventory status condition (type: DBaaSInventoryReadyType) based on the provider status
 	specSync := apimeta.FindStatusCondition(providerInv.Status.Conditions, v1alpha1.DBaaSInventoryProviderSyncType)
+	setInventorySyncConditions(inv, specSync, providerCredentials != nil)
 	if specSync != nil && specSync.Status == metav1.ConditionTrue {
 		return metav1.Condition{
 			Type:    v1alpha1.DBaaSInventoryReadyType,
@@ -155,6 +615,55 @@ func mergeInventoryStatus(inv *v1alpha1.DBaaSInventory, providerInv *v1alpha1.DB
 	}
 }
 
+// getExposedInstances returns the discovered instances selected by the instance filter of an inventory
+func getExposedInstances(inv *v1alpha1.DBaaSInventory, instances []v1alpha1.Instance) []v1alpha1.Instance {
+	if inv.Spec.InstanceFilter == nil {
+		return instances
+	}
+	exposed := make([]v1alpha1.Instance, 0, len(instances))
+	for _, instance := range instances {
+		if inv.Spec.InstanceFilter.Matches(instance) {
+			exposed = append(exposed, instance)
+		}
+	}
+	return exposed
+}
+
+// setInventorySyncConditions sets the Synced and CredentialsValid conditions and the last sync time of an inventory
+// from the sync condition reported by the provider. a discovery failing to authenticate invalidates the credentials
+// with the error of the provider, other failures leave them unverified, unless the provider reports its own
+// CredentialsValid condition.
+func setInventorySyncConditions(inv *v1alpha1.DBaaSInventory, specSync *metav1.Condition, providerCredentials bool) {
+	synced := metav1.Condition{
+		Type:    v1alpha1.DBaaSInventorySyncedType,
+		Status:  metav1.ConditionUnknown,
+		Reason:  v1alpha1.SyncPending,
+		Message: v1alpha1.MsgSyncPending,
+	}
+	credentials := metav1.Condition{
+		Type:    v1alpha1.DBaaSInventoryCredentialsType,
+		Status:  metav1.ConditionUnknown,
+		Reason:  v1alpha1.CredentialsUnverified,
+		Message: v1alpha1.MsgCredentialsUnverified,
+	}
+	if specSync != nil {
+		synced.Status, synced.Reason, synced.Message = specSync.Status, specSync.Reason, specSync.Message
+		if specSync.Status == metav1.ConditionTrue {
+			credentials.Status, credentials.Reason, credentials.Message = metav1.ConditionTrue, v1alpha1.CredentialsVerified, v1alpha1.MsgCredentialsVerified
+			if inv.Status.LastSyncTime == nil || inv.Status.LastSyncTime.Before(&specSync.LastTransitionTime) {
+				lastSyncTime := specSync.LastTransitionTime
+				inv.Status.LastSyncTime = &lastSyncTime
+			}
+		} else if specSync.Status == metav1.ConditionFalse && v1alpha1.IsAuthenticationFailure(specSync.Reason) {
+			credentials.Status, credentials.Reason, credentials.Message = metav1.ConditionFalse, specSync.Reason, specSync.Message
+		}
+	}
+	apimeta.SetStatusCondition(&inv.Status.Conditions, synced)
+	if !providerCredentials {
+		apimeta.SetStatusCondition(&inv.Status.Conditions, credentials)
+	}
+}
+
 // Delete implements a handler for the Delete event.
 func (r *DBaaSInventoryReconciler) Delete(e event.DeleteEvent) error {
 	log := ctrl.Log.WithName("DBaaSInventoryReconciler DeleteEvent")
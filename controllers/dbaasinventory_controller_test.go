@@ -17,12 +17,17 @@ limitations under the License.
 package controllers
 
 import (
+	"fmt"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 )
@@ -165,7 +170,280 @@ var _ = Describe("DBaaSInventory controller - nominal", func() {
 					Expect(labels).Should(Not(BeNil()))
 					Expect(labels[v1alpha1.TypeLabelKeyMongo]).Should(Equal(v1alpha1.TypeLabelValue))
 				})
+				It("should resync the provider inventory when the secret changes", func() {
+					secret := v1.Secret{}
+					Eventually(func() error {
+						if err := dRec.Get(ctx, client.ObjectKeyFromObject(&updatedTestSecret), &secret); err != nil {
+							return err
+						}
+						secret.StringData = map[string]string{"field1": "updated"}
+						return dRec.Update(ctx, &secret)
+					}, timeout).Should(Succeed())
+
+					providerInventory := &unstructured.Unstructured{}
+					providerInventory.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(testInventoryKind))
+					Eventually(func() string {
+						if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), providerInventory); err != nil {
+							return ""
+						}
+						return providerInventory.GetAnnotations()[v1alpha1.CredentialsResourceVersionAnnotation]
+					}, timeout).Should(Equal(secret.ResourceVersion))
+					Eventually(func() string {
+						inventory := &v1alpha1.DBaaSInventory{}
+						if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), inventory); err != nil {
+							return ""
+						}
+						return inventory.Status.CredentialsResourceVersion
+					}, timeout).Should(Equal(secret.ResourceVersion))
+				})
 			})
 		})
 	})
 })
+
+var _ = Describe("DBaaSInventory sync status", func() {
+	It("should derive the sync conditions from the provider status", func() {
+		inventory := &v1alpha1.DBaaSInventory{}
+		lastTransitionTime := metav1.Time{Time: getLastTransitionTimeForTest()}
+		providerInventory := &v1alpha1.DBaaSProviderInventory{
+			Status: v1alpha1.DBaaSInventoryStatus{
+				Instances: []v1alpha1.Instance{{InstanceID: "instance-1"}, {InstanceID: "instance-2"}},
+				Conditions: []metav1.Condition{
+					{
+						Type:               v1alpha1.DBaaSInventoryProviderSyncType,
+						Status:             metav1.ConditionTrue,
+						Reason:             "SyncOK",
+						LastTransitionTime: lastTransitionTime,
+					},
+				},
+			},
+		}
+		mergeInventoryStatus(inventory, providerInventory)
+		Expect(inventory.Status.ObservedInstanceCount).Should(Equal(int32(2)))
+		Expect(inventory.Status.LastSyncTime).Should(Equal(&lastTransitionTime))
+		Expect(apimeta.IsStatusConditionTrue(inventory.Status.Conditions, v1alpha1.DBaaSInventorySyncedType)).Should(BeTrue())
+		cond := apimeta.FindStatusCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventoryCredentialsType)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).Should(Equal(v1alpha1.CredentialsVerified))
+	})
+
+	It("should invalidate the credentials when the discovery fails", func() {
+		inventory := &v1alpha1.DBaaSInventory{}
+		providerInventory := &v1alpha1.DBaaSProviderInventory{
+			Status: v1alpha1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    v1alpha1.DBaaSInventoryProviderSyncType,
+						Status:  metav1.ConditionFalse,
+						Reason:  "AuthenticationFailed",
+						Message: "invalid API key",
+					},
+				},
+			},
+		}
+		mergeInventoryStatus(inventory, providerInventory)
+		cond := apimeta.FindStatusCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventoryCredentialsType)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).Should(Equal("AuthenticationFailed"))
+		Expect(cond.Message).Should(Equal("invalid API key"))
+	})
+
+	It("should not invalidate the credentials when the discovery fails for another reason", func() {
+		inventory := &v1alpha1.DBaaSInventory{}
+		providerInventory := &v1alpha1.DBaaSProviderInventory{
+			Status: v1alpha1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    v1alpha1.DBaaSInventoryProviderSyncType,
+						Status:  metav1.ConditionFalse,
+						Reason:  "BackendError",
+						Message: "service unavailable",
+					},
+				},
+			},
+		}
+		mergeInventoryStatus(inventory, providerInventory)
+		cond := apimeta.FindStatusCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventoryCredentialsType)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionUnknown))
+		Expect(cond.Reason).Should(Equal(v1alpha1.CredentialsUnverified))
+	})
+
+	It("should keep the credentials condition reported by the provider", func() {
+		inventory := &v1alpha1.DBaaSInventory{}
+		providerInventory := &v1alpha1.DBaaSProviderInventory{
+			Status: v1alpha1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   v1alpha1.DBaaSInventoryProviderSyncType,
+						Status: metav1.ConditionFalse,
+						Reason: "BackendError",
+					},
+					{
+						Type:   v1alpha1.DBaaSInventoryCredentialsType,
+						Status: metav1.ConditionFalse,
+						Reason: "Unauthorized",
+					},
+				},
+			},
+		}
+		mergeInventoryStatus(inventory, providerInventory)
+		Expect(inventory.Status.LastSyncTime).Should(BeNil())
+		synced := apimeta.FindStatusCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventorySyncedType)
+		Expect(synced).ShouldNot(BeNil())
+		Expect(synced.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(synced.Reason).Should(Equal("BackendError"))
+		cond := apimeta.FindStatusCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventoryCredentialsType)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Reason).Should(Equal("Unauthorized"))
+	})
+
+	It("should only expose the instances selected by the instance filter", func() {
+		inventory := &v1alpha1.DBaaSInventory{}
+		inventory.Spec.InstanceFilter = &v1alpha1.DBaaSInventoryInstanceFilter{
+			NamePatterns:        []string{"dev-*"},
+			ExcludeNamePatterns: []string{"*-legacy"},
+			InstanceInfo:        map[string]string{"tier": "free"},
+		}
+		providerInventory := &v1alpha1.DBaaSProviderInventory{
+			Status: v1alpha1.DBaaSInventoryStatus{
+				Instances: []v1alpha1.Instance{
+					{InstanceID: "1", Name: "dev-orders", InstanceInfo: map[string]string{"tier": "free"}},
+					{InstanceID: "2", Name: "dev-orders-legacy", InstanceInfo: map[string]string{"tier": "free"}},
+					{InstanceID: "3", Name: "dev-billing", InstanceInfo: map[string]string{"tier": "dedicated"}},
+					{InstanceID: "4", Name: "prod-orders", InstanceInfo: map[string]string{"tier": "free"}},
+				},
+			},
+		}
+		mergeInventoryStatus(inventory, providerInventory)
+		Expect(inventory.Status.ObservedInstanceCount).Should(Equal(int32(4)))
+		Expect(inventory.Status.Instances).Should(HaveLen(1))
+		Expect(inventory.Status.Instances[0].InstanceID).Should(Equal("1"))
+	})
+
+	It("should pass refresh requests to the provider inventory", func() {
+		refreshTime := metav1.NewTime(time.Date(2022, time.May, 7, 10, 30, 0, 0, time.UTC))
+		inventory := &v1alpha1.DBaaSInventory{}
+		inventory.Status.LastRefreshTime = &refreshTime
+		inventory.Status.CredentialsResourceVersion = "42"
+		providerInventory := &unstructured.Unstructured{}
+		setProviderInventoryAnnotations(inventory, providerInventory)
+		Expect(providerInventory.GetAnnotations()).Should(Equal(map[string]string{
+			v1alpha1.RefreshTimeAnnotation:                "2022-05-07T10:30:00Z",
+			v1alpha1.CredentialsResourceVersionAnnotation: "42",
+		}))
+	})
+})
+
+var _ = Describe("DBaaSInventory dependents summary", func() {
+	It("should count and list the dependents by namespace", func() {
+		connectionList := &v1alpha1.DBaaSConnectionList{}
+		for i := 0; i < maxDependentNames+2; i++ {
+			connectionList.Items = append(connectionList.Items, v1alpha1.DBaaSConnection{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("connection-%02d", i), Namespace: "ns-b"},
+			})
+		}
+		instanceList := &v1alpha1.DBaaSInstanceList{
+			Items: []v1alpha1.DBaaSInstance{
+				{ObjectMeta: metav1.ObjectMeta{Name: "instance-2", Namespace: "ns-a"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "instance-1", Namespace: "ns-a"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "instance-3", Namespace: "ns-b"}},
+			},
+		}
+		dependents := getInventoryDependents(connectionList, instanceList)
+		Expect(dependents.ConnectionCount).Should(Equal(int32(maxDependentNames + 2)))
+		Expect(dependents.InstanceCount).Should(Equal(int32(3)))
+		Expect(dependents.NamespaceCount).Should(Equal(int32(2)))
+		Expect(dependents.Namespaces).Should(HaveLen(2))
+		Expect(dependents.Namespaces[0]).Should(Equal(v1alpha1.DBaaSInventoryNamespaceDependents{
+			Namespace:     "ns-a",
+			InstanceCount: 2,
+			Instances:     []string{"instance-1", "instance-2"},
+		}))
+		Expect(dependents.Namespaces[1].Namespace).Should(Equal("ns-b"))
+		Expect(dependents.Namespaces[1].ConnectionCount).Should(Equal(int32(maxDependentNames + 2)))
+		Expect(dependents.Namespaces[1].Connections).Should(HaveLen(maxDependentNames))
+		Expect(dependents.Namespaces[1].Connections[0]).Should(Equal("connection-00"))
+		Expect(dependents.Namespaces[1].Instances).Should(Equal([]string{"instance-3"}))
+	})
+
+	It("should cap the listed namespaces", func() {
+		instanceList := &v1alpha1.DBaaSInstanceList{}
+		for i := 0; i < maxDependentNamespaces+1; i++ {
+			instanceList.Items = append(instanceList.Items, v1alpha1.DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: fmt.Sprintf("ns-%03d", i)},
+			})
+		}
+		dependents := getInventoryDependents(&v1alpha1.DBaaSConnectionList{}, instanceList)
+		Expect(dependents.NamespaceCount).Should(Equal(int32(maxDependentNamespaces + 1)))
+		Expect(dependents.Namespaces).Should(HaveLen(maxDependentNamespaces))
+	})
+})
+
+var _ = Describe("DBaaSInventory discovered instances", func() {
+	inventory := &v1alpha1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-inventory-discovered",
+			Namespace: testNamespace,
+		},
+		Spec: v1alpha1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1alpha1.NamespacedName{Name: "test-provider-discovered"},
+			DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+				CredentialsRef: &v1alpha1.LocalObjectReference{Name: "test-credentials-discovered"},
+			},
+		},
+	}
+	BeforeEach(assertResourceCreation(inventory))
+	AfterEach(assertResourceDeletion(inventory))
+
+	It("should project the exposed instances into DBaaSDiscoveredInstances", func() {
+		r := &DBaaSInventoryReconciler{DBaaSReconciler: dRec}
+		instances := []v1alpha1.Instance{
+			{InstanceID: "cluster/1", Name: "orders", InstanceInfo: map[string]string{"region": "us-east-1"}},
+			{InstanceID: "cluster/2", Name: "billing"},
+		}
+		Expect(r.syncDiscoveredInstances(ctx, inventory, instances)).Should(Succeed())
+
+		discovered := &v1alpha1.DBaaSDiscoveredInstance{}
+		key := client.ObjectKey{Name: v1alpha1.DiscoveredInstanceName(inventory.Name, "cluster/1"), Namespace: testNamespace}
+		Eventually(func() error {
+			return dRec.Get(ctx, key, discovered)
+		}, timeout).Should(Succeed())
+		Expect(discovered.Labels).Should(HaveKeyWithValue(v1alpha1.ProviderLabelKey, "test-provider-discovered"))
+		Expect(discovered.Labels).Should(HaveKeyWithValue(v1alpha1.InventoryLabelKey, inventory.Name))
+		Expect(discovered.Spec.Instance).Should(Equal(instances[0]))
+		Expect(discovered.OwnerReferences).Should(HaveLen(1))
+		Expect(discovered.OwnerReferences[0].Name).Should(Equal(inventory.Name))
+
+		By("deleting the instances no longer discovered")
+		Expect(r.syncDiscoveredInstances(ctx, inventory, instances[:1])).Should(Succeed())
+		Eventually(func() int {
+			var discoveredList v1alpha1.DBaaSDiscoveredInstanceList
+			if err := dRec.List(ctx, &discoveredList, client.InNamespace(testNamespace), client.MatchingLabels{v1alpha1.InventoryLabelKey: inventory.Name}); err != nil {
+				return -1
+			}
+			return len(discoveredList.Items)
+		}, timeout).Should(Equal(1))
+	})
+})
+
+var _ = Describe("DBaaSInventory policy violation", func() {
+	It("should report the inventories of providers denied by the policy", func() {
+		inventory := &v1alpha1.DBaaSInventory{
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1alpha1.NamespacedName{Name: testProviderName},
+			},
+		}
+		setPolicyViolationCondition(inventory, &v1alpha1.DBaaSInventoryPolicy{DeniedProviders: []string{testProviderName}})
+		cond := apimeta.FindStatusCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventoryPolicyViolation)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).Should(Equal(v1alpha1.DBaaSProviderNotAllowed))
+
+		By("removing the condition once the provider is allowed")
+		setPolicyViolationCondition(inventory, &v1alpha1.DBaaSInventoryPolicy{AllowedProviders: &[]string{testProviderName}})
+		Expect(apimeta.FindStatusCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventoryPolicyViolation)).Should(BeNil())
+	})
+})
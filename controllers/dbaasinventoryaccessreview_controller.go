@@ -0,0 +1,112 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// DBaaSInventoryAccessReviewReconciler reconciles a DBaaSInventoryAccessReview object
+type DBaaSInventoryAccessReviewReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
+func (r *DBaaSInventoryAccessReviewReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var review v1alpha1.DBaaSInventoryAccessReview
+	if err := r.Get(ctx, req.NamespacedName, &review); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("DBaaS Inventory Access Review resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Inventory Access Review for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	inventoryRef := review.Spec.InventoryRef
+	if len(inventoryRef.Namespace) == 0 {
+		inventoryRef.Namespace = review.Namespace
+	}
+	review.Status = v1alpha1.DBaaSInventoryAccessReviewStatus{
+		ObservedGeneration: review.Generation,
+	}
+	var inventory v1alpha1.DBaaSInventory
+	if err := r.Get(ctx, types.NamespacedName{Namespace: inventoryRef.Namespace, Name: inventoryRef.Name}, &inventory); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Error fetching the DBaaS Inventory of the DBaaS Inventory Access Review", "DBaaS Inventory", inventoryRef)
+			return ctrl.Result{}, err
+		}
+		// a missing inventory is reported as a denied namespace, so that reviews do not reveal which inventories exist
+		review.Status.Reason = v1alpha1.DBaaSInvalidNamespace
+		review.Status.Message = v1alpha1.MsgInvalidNamespace
+	} else {
+		validNS, err := v1alpha1.IsValidConnectionNamespace(ctx, r.Client, review.Namespace, &inventory)
+		if err != nil {
+			logger.Error(err, "Error checking the namespace of the DBaaS Inventory Access Review", "DBaaS Inventory", inventoryRef)
+			return ctrl.Result{}, err
+		}
+		review.Status.Allowed = validNS
+		if validNS {
+			review.Status.Reason = v1alpha1.DBaaSNamespaceAllowed
+			review.Status.Message = v1alpha1.MsgNamespaceAllowed
+		} else {
+			review.Status.Reason = v1alpha1.DBaaSInvalidNamespace
+			review.Status.Message = v1alpha1.MsgInvalidNamespace
+		}
+	}
+
+	if err := r.Client.Status().Update(ctx, &review); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Inventory Access Review modified, retry syncing status", "DBaaS Inventory Access Review", review)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Inventory Access Review status", "DBaaS Inventory Access Review", review)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSInventoryAccessReviewReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// index review by the inventory it references, to re-evaluate the reviews of an inventory when its policy changes
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.DBaaSInventoryAccessReview{}, v1alpha1.InventoryRefKey, v1alpha1.InventoryRefIndexer(mgr.GetClient())); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBaaSInventoryAccessReview{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	return r.watchNamespacePolicies(b, func() client.ObjectList { return &v1alpha1.DBaaSInventoryAccessReviewList{} }).
+		Complete(r)
+}
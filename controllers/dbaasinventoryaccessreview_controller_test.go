@@ -0,0 +1,176 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+var _ = Describe("DBaaSInventoryAccessReview controller", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	getReviewStatus := func(review *v1alpha1.DBaaSInventoryAccessReview) func() (v1alpha1.DBaaSInventoryAccessReviewStatus, error) {
+		return func() (v1alpha1.DBaaSInventoryAccessReviewStatus, error) {
+			getReview := &v1alpha1.DBaaSInventoryAccessReview{}
+			err := dRec.Get(ctx, client.ObjectKeyFromObject(review), getReview)
+			return getReview.Status, err
+		}
+	}
+
+	Context("after creating DBaaSInventoryAccessReview without inventory", func() {
+		createdReview := &v1alpha1.DBaaSInventoryAccessReview{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-review-no-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSInventoryAccessReviewSpec{
+				InventoryRef: v1alpha1.NamespacedName{Name: "test-review-inventory-no-exist"},
+			},
+		}
+		BeforeEach(assertResourceCreation(createdReview))
+		AfterEach(assertResourceDeletion(createdReview))
+		It("should deny the access as for a denied namespace", func() {
+			Eventually(getReviewStatus(createdReview), timeout).Should(And(
+				HaveField("Allowed", false),
+				HaveField("Reason", v1alpha1.DBaaSInvalidNamespace),
+				HaveField("Message", v1alpha1.MsgInvalidNamespace),
+			))
+		})
+	})
+
+	Context("after creating DBaaSInventoryAccessReviews for an inventory allowing a single other namespace", func() {
+		allowedNS := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-review-allowed",
+			},
+		}
+		deniedNS := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-review-denied",
+			},
+		}
+		inventory := getBackupTestInventory("test-review-inventory", testProviderName)
+		inventory.Spec.ConnectionNamespaces = &[]string{allowedNS.Name}
+		inventoryRef := v1alpha1.NamespacedName{Name: inventory.Name, Namespace: inventory.Namespace}
+		allowedReview := &v1alpha1.DBaaSInventoryAccessReview{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-review",
+				Namespace: allowedNS.Name,
+			},
+			Spec: v1alpha1.DBaaSInventoryAccessReviewSpec{
+				InventoryRef: inventoryRef,
+			},
+		}
+		deniedReview := &v1alpha1.DBaaSInventoryAccessReview{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-review",
+				Namespace: deniedNS.Name,
+			},
+			Spec: v1alpha1.DBaaSInventoryAccessReviewSpec{
+				InventoryRef: inventoryRef,
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(allowedNS))
+		BeforeEach(assertResourceCreationIfNotExists(deniedNS))
+		BeforeEach(assertResourceCreation(inventory))
+		BeforeEach(assertResourceCreation(allowedReview))
+		BeforeEach(assertResourceCreation(deniedReview))
+		AfterEach(assertResourceDeletion(deniedReview))
+		AfterEach(assertResourceDeletion(allowedReview))
+		AfterEach(assertResourceDeletion(inventory))
+
+		It("should allow the listed namespace only", func() {
+			Eventually(getReviewStatus(allowedReview), timeout).Should(And(
+				HaveField("Allowed", true),
+				HaveField("Reason", v1alpha1.DBaaSNamespaceAllowed),
+			))
+			Eventually(getReviewStatus(deniedReview), timeout).Should(And(
+				HaveField("Allowed", false),
+				HaveField("Reason", v1alpha1.DBaaSInvalidNamespace),
+			))
+		})
+
+		It("should re-evaluate the reviews when the inventory policy changes", func() {
+			Eventually(getReviewStatus(deniedReview), timeout).Should(HaveField("Allowed", false))
+
+			By("allowing the denied namespace in the inventory")
+			Eventually(func() error {
+				getInventory := &v1alpha1.DBaaSInventory{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(inventory), getInventory); err != nil {
+					return err
+				}
+				getInventory.Spec.ConnectionNamespaces = &[]string{allowedNS.Name, deniedNS.Name}
+				return dRec.Update(ctx, getInventory)
+			}, timeout).Should(Succeed())
+
+			Eventually(getReviewStatus(deniedReview), timeout).Should(And(
+				HaveField("Allowed", true),
+				HaveField("Reason", v1alpha1.DBaaSNamespaceAllowed),
+			))
+		})
+
+		It("should report the allowed namespaces in the inventory status", func() {
+			Eventually(func() (*v1alpha1.DBaaSAllowedNamespaces, error) {
+				getInventory := &v1alpha1.DBaaSInventory{}
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(inventory), getInventory)
+				return getInventory.Status.AllowedNamespaces, err
+			}, timeout).Should(Equal(&v1alpha1.DBaaSAllowedNamespaces{
+				Namespaces: []string{testNamespace, allowedNS.Name},
+				Count:      2,
+			}))
+		})
+	})
+
+	Context("after creating a DBaaSInventory selecting the allowed namespaces by label", func() {
+		selectedNS := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "test-review-selected",
+				Labels: map[string]string{"dbaas-test": "review-selected"},
+			},
+		}
+		inventory := getBackupTestInventory("test-review-selector-inventory", testProviderName)
+		// override the "*" wildcard of the default policy
+		inventory.Spec.ConnectionNamespaces = &[]string{}
+		inventory.Spec.ConnectionNsSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"dbaas-test": "review-selected"},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(selectedNS))
+		BeforeEach(assertResourceCreation(inventory))
+		AfterEach(assertResourceDeletion(inventory))
+
+		It("should report the selected namespaces in the inventory status", func() {
+			Eventually(func() (*v1alpha1.DBaaSAllowedNamespaces, error) {
+				getInventory := &v1alpha1.DBaaSInventory{}
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(inventory), getInventory)
+				return getInventory.Status.AllowedNamespaces, err
+			}, timeout).Should(Equal(&v1alpha1.DBaaSAllowedNamespaces{
+				Namespaces: []string{testNamespace, selectedNS.Name},
+				Count:      2,
+			}))
+		})
+	})
+})
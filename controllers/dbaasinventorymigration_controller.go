@@ -0,0 +1,318 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// migrationRetryInterval is how often a migration waits for its target inventory to be ready
+const migrationRetryInterval = 30 * time.Second
+
+// DBaaSInventoryMigrationReconciler reconciles a DBaaSInventoryMigration object
+type DBaaSInventoryMigrationReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
+func (r *DBaaSInventoryMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var migration v1alpha1.DBaaSInventoryMigration
+	if err := r.Get(ctx, req.NamespacedName, &migration); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("DBaaS Inventory Migration resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Inventory Migration for reconcile")
+		return ctrl.Result{}, err
+	}
+	// a migration runs once
+	if migration.Status.Phase == v1alpha1.MigrationPhaseCompleted || migration.Status.Phase == v1alpha1.MigrationPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	updateStatus := func(phase v1alpha1.DBaaSInventoryMigrationPhase, status metav1.ConditionStatus, reason, message string) (ctrl.Result, error) {
+		migration.Status.Phase = phase
+		apimeta.SetStatusCondition(&migration.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.DBaaSInventoryMigratedType,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+		if err := r.Client.Status().Update(ctx, &migration); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Inventory Migration modified, retry syncing status", "DBaaS Inventory Migration", migration)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error updating the DBaaS Inventory Migration status", "DBaaS Inventory Migration", migration)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	var source, target v1alpha1.DBaaSInventory
+	if err := r.Get(ctx, types.NamespacedName{Name: migration.Spec.SourceInventoryRef.Name, Namespace: migration.Namespace}, &source); err != nil {
+		if errors.IsNotFound(err) {
+			return updateStatus(v1alpha1.MigrationPhaseFailed, metav1.ConditionFalse, v1alpha1.DBaaSInventoryNotFound,
+				fmt.Sprintf("source inventory %s not found", migration.Spec.SourceInventoryRef.Name))
+		}
+		return ctrl.Result{}, err
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: migration.Spec.TargetInventoryRef.Name, Namespace: migration.Namespace}, &target); err != nil {
+		if errors.IsNotFound(err) {
+			return updateStatus(v1alpha1.MigrationPhaseFailed, metav1.ConditionFalse, v1alpha1.DBaaSInventoryNotFound,
+				fmt.Sprintf("target inventory %s not found", migration.Spec.TargetInventoryRef.Name))
+		}
+		return ctrl.Result{}, err
+	}
+	if source.Name == target.Name || source.Spec.ProviderRef.Name != target.Spec.ProviderRef.Name {
+		return updateStatus(v1alpha1.MigrationPhaseFailed, metav1.ConditionFalse, v1alpha1.DBaaSMigrationProviderMismatch, v1alpha1.MsgMigrationProviderMismatch)
+	}
+	if !apimeta.IsStatusConditionTrue(target.Status.Conditions, v1alpha1.DBaaSInventoryReadyType) {
+		logger.Info("Target DBaaS Inventory not ready, waiting", "DBaaS Inventory", target.Name)
+		result, err := updateStatus(v1alpha1.MigrationPhasePending, metav1.ConditionFalse, v1alpha1.DBaaSInventoryNotReady, v1alpha1.MsgInventoryNotReady)
+		if err == nil && !result.Requeue {
+			result.RequeueAfter = migrationRetryInterval
+		}
+		return result, err
+	}
+	provider, err := r.getDBaaSProvider(ctx, target.Spec.ProviderRef.Name)
+	if err != nil {
+		logger.Error(err, "Error fetching the DBaaS Provider of the target DBaaS Inventory")
+		return ctrl.Result{}, err
+	}
+
+	// saveProgress keeps the status of the dependents migrated before an error
+	saveProgress := func(err error) (ctrl.Result, error) {
+		if result, errStatus := updateStatus(v1alpha1.MigrationPhaseInProgress, metav1.ConditionFalse, v1alpha1.DBaaSMigrationInProgress, v1alpha1.MsgMigrationInProgress); errStatus != nil || result.Requeue {
+			return result, errStatus
+		}
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error migrating the dependents of the source DBaaS Inventory")
+		return ctrl.Result{}, err
+	}
+	// the migrated dependents no longer reference the source inventory, their status is kept across reconciles
+	migrated := map[string]bool{}
+	for _, dependent := range migration.Status.Dependents {
+		migrated[dependent.Kind+"/"+dependent.Namespace+"/"+dependent.Name] = true
+	}
+	connectionList, instanceList, err := r.listInventoryDependents(ctx, &source)
+	if err != nil {
+		logger.Error(err, "Error listing the dependents of the source DBaaS Inventory")
+		return ctrl.Result{}, err
+	}
+	// the instances are migrated first, the connections to an instance that failed to migrate are not migrated
+	for i := range instanceList.Items {
+		instance := &instanceList.Items[i]
+		if migrated["DBaaSInstance/"+instance.Namespace+"/"+instance.Name] {
+			continue
+		}
+		dependent, err := r.migrateInstance(ctx, &migration, &target, instance)
+		if err != nil {
+			return saveProgress(err)
+		}
+		migration.Status.Dependents = append(migration.Status.Dependents, dependent)
+	}
+	failedInstances := getFailedMigrationInstances(migration.Status.Dependents)
+	for i := range connectionList.Items {
+		connection := &connectionList.Items[i]
+		if migrated["DBaaSConnection/"+connection.Namespace+"/"+connection.Name] {
+			continue
+		}
+		var dependent v1alpha1.DBaaSInventoryMigrationDependent
+		if instance, failed := failedInstances.find(connection); failed {
+			dependent = failMigrationDependent(newMigrationDependent("DBaaSConnection", connection, connection.Spec.InstanceID),
+				fmt.Sprintf("instance %s failed to migrate", instance))
+		} else if dependent, err = r.migrateConnection(ctx, &migration, &target, provider, connection); err != nil {
+			return saveProgress(err)
+		}
+		migration.Status.Dependents = append(migration.Status.Dependents, dependent)
+	}
+
+	migration.Status.MigratedCount, migration.Status.FailedCount = 0, 0
+	for _, dependent := range migration.Status.Dependents {
+		if dependent.Phase == v1alpha1.MigrationDependentMigrated {
+			migration.Status.MigratedCount++
+		} else {
+			migration.Status.FailedCount++
+		}
+	}
+	if migration.Status.FailedCount > 0 {
+		logger.Info("DBaaS Inventory Migration failed", "Migrated", migration.Status.MigratedCount, "Failed", migration.Status.FailedCount)
+		return updateStatus(v1alpha1.MigrationPhaseFailed, metav1.ConditionFalse, v1alpha1.DBaaSMigrationFailed, v1alpha1.MsgMigrationFailed)
+	}
+	logger.Info("DBaaS Inventory Migration completed", "Migrated", migration.Status.MigratedCount)
+	return updateStatus(v1alpha1.MigrationPhaseCompleted, metav1.ConditionTrue, v1alpha1.Ready, v1alpha1.MsgMigrationCompleted)
+}
+
+// migrateConnection re-points a connection to the target inventory, and deletes its provider connection so that
+// the connection controller recreates it with the target inventory. connections to a DBaaSInstance follow the instance.
+func (r *DBaaSInventoryMigrationReconciler) migrateConnection(ctx context.Context, migration *v1alpha1.DBaaSInventoryMigration, target *v1alpha1.DBaaSInventory,
+	provider *v1alpha1.DBaaSProvider, connection *v1alpha1.DBaaSConnection) (v1alpha1.DBaaSInventoryMigrationDependent, error) {
+	dependent := newMigrationDependent("DBaaSConnection", connection, connection.Spec.InstanceID)
+	if len(connection.Spec.InstanceID) > 0 {
+		found, err := r.inventoryHasInstance(ctx, target, connection.Spec.InstanceID)
+		if err != nil {
+			return dependent, err
+		}
+		if !found {
+			return failMigrationDependent(dependent, fmt.Sprintf("instance %s not found in inventory %s", connection.Spec.InstanceID, target.Name)), nil
+		}
+	}
+	setMigration(connection, migration)
+	connection.Spec.InventoryRef = v1alpha1.NamespacedName{Name: target.Name, Namespace: target.Namespace}
+	if err := r.Update(ctx, connection); err != nil {
+		if errors.IsConflict(err) {
+			return dependent, err
+		}
+		return failMigrationDependent(dependent, err.Error()), nil
+	}
+	if err := r.Client.Delete(ctx, r.createProviderObject(connection, provider.Spec.ConnectionKind)); err != nil && !errors.IsNotFound(err) {
+		return failMigrationDependent(dependent, err.Error()), nil
+	}
+	ctrl.LoggerFrom(ctx).Info("DBaaS Connection migrated", "DBaaS Connection", client.ObjectKeyFromObject(connection), "DBaaS Inventory", target.Name)
+	return dependent, nil
+}
+
+// migrateInstance re-points a provisioned instance to the target inventory. the provider instance is updated in place
+// by the instance controller, it is not recreated: deleting it would deprovision the database.
+func (r *DBaaSInventoryMigrationReconciler) migrateInstance(ctx context.Context, migration *v1alpha1.DBaaSInventoryMigration, target *v1alpha1.DBaaSInventory,
+	instance *v1alpha1.DBaaSInstance) (v1alpha1.DBaaSInventoryMigrationDependent, error) {
+	dependent := newMigrationDependent("DBaaSInstance", instance, instance.Status.InstanceID)
+	if len(instance.Status.InstanceID) == 0 {
+		return failMigrationDependent(dependent, "instance is not provisioned"), nil
+	}
+	found, err := r.inventoryHasInstance(ctx, target, instance.Status.InstanceID)
+	if err != nil {
+		return dependent, err
+	}
+	if !found {
+		return failMigrationDependent(dependent, fmt.Sprintf("instance %s not found in inventory %s", instance.Status.InstanceID, target.Name)), nil
+	}
+	setMigration(instance, migration)
+	instance.Spec.InventoryRef = v1alpha1.NamespacedName{Name: target.Name, Namespace: target.Namespace}
+	if err := r.Update(ctx, instance); err != nil {
+		if errors.IsConflict(err) {
+			return dependent, err
+		}
+		return failMigrationDependent(dependent, err.Error()), nil
+	}
+	ctrl.LoggerFrom(ctx).Info("DBaaS Instance migrated", "DBaaS Instance", client.ObjectKeyFromObject(instance), "DBaaS Inventory", target.Name)
+	return dependent, nil
+}
+
+// failedMigrationInstances are the instances that failed to migrate, by namespace and name and by instance ID
+type failedMigrationInstances struct {
+	byName map[types.NamespacedName]bool
+	byID   map[string]bool
+}
+
+// getFailedMigrationInstances returns the instances that failed to migrate
+func getFailedMigrationInstances(dependents []v1alpha1.DBaaSInventoryMigrationDependent) failedMigrationInstances {
+	failed := failedMigrationInstances{byName: map[types.NamespacedName]bool{}, byID: map[string]bool{}}
+	for _, dependent := range dependents {
+		if dependent.Kind != "DBaaSInstance" || dependent.Phase != v1alpha1.MigrationDependentFailed {
+			continue
+		}
+		failed.byName[types.NamespacedName{Namespace: dependent.Namespace, Name: dependent.Name}] = true
+		if len(dependent.InstanceID) > 0 {
+			failed.byID[dependent.InstanceID] = true
+		}
+	}
+	return failed
+}
+
+// find returns the instance of a connection that failed to migrate, if any
+func (f failedMigrationInstances) find(connection *v1alpha1.DBaaSConnection) (string, bool) {
+	if ref := connection.Spec.InstanceRef; ref != nil {
+		key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		return key.String(), f.byName[key]
+	}
+	return connection.Spec.InstanceID, f.byID[connection.Spec.InstanceID]
+}
+
+// inventoryHasInstance checks whether an inventory exposes an instance, in its status or its DBaaSDiscoveredInstances
+func (r *DBaaSInventoryMigrationReconciler) inventoryHasInstance(ctx context.Context, inventory *v1alpha1.DBaaSInventory, instanceID string) (bool, error) {
+	if inventory.Spec.OmitStatusInstances {
+		discovered := &v1alpha1.DBaaSDiscoveredInstance{}
+		if err := r.Get(ctx, types.NamespacedName{Name: v1alpha1.DiscoveredInstanceName(inventory.Name, instanceID), Namespace: inventory.Namespace}, discovered); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return discovered.Spec.InstanceID == instanceID, nil
+	}
+	for _, instance := range getExposedInstances(inventory, inventory.Status.Instances) {
+		if instance.InstanceID == instanceID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// setMigration annotates a dependent with the migration changing its inventory reference, which the webhooks allow
+func setMigration(dependent client.Object, migration *v1alpha1.DBaaSInventoryMigration) {
+	annotations := dependent.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1alpha1.MigrationAnnotation] = migration.Name
+	dependent.SetAnnotations(annotations)
+}
+
+func newMigrationDependent(kind string, dependent client.Object, instanceID string) v1alpha1.DBaaSInventoryMigrationDependent {
+	return v1alpha1.DBaaSInventoryMigrationDependent{
+		Kind:               kind,
+		Namespace:          dependent.GetNamespace(),
+		Name:               dependent.GetName(),
+		InstanceID:         instanceID,
+		Phase:              v1alpha1.MigrationDependentMigrated,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+func failMigrationDependent(dependent v1alpha1.DBaaSInventoryMigrationDependent, message string) v1alpha1.DBaaSInventoryMigrationDependent {
+	dependent.Phase = v1alpha1.MigrationDependentFailed
+	dependent.Message = message
+	return dependent
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSInventoryMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBaaSInventoryMigration{}).
+		Complete(r)
+}
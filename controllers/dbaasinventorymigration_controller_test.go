@@ -0,0 +1,142 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+var _ = Describe("DBaaSInventoryMigration controller", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Context("after creating DBaaSInventoryMigration without source inventory", func() {
+		createdMigration := &v1alpha1.DBaaSInventoryMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-migration-no-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSInventoryMigrationSpec{
+				SourceInventoryRef: v1alpha1.LocalObjectReference{Name: "test-migration-inventory-no-exist"},
+				TargetInventoryRef: v1alpha1.LocalObjectReference{Name: "test-migration-target-no-exist"},
+			},
+		}
+		BeforeEach(assertResourceCreation(createdMigration))
+		AfterEach(assertResourceDeletion(createdMigration))
+		It("should fail the migration", assertDBaaSResourceStatusUpdated(createdMigration, metav1.ConditionFalse, v1alpha1.DBaaSInventoryNotFound))
+	})
+
+	Context("after creating DBaaSInventoryMigration between inventories of different providers", func() {
+		sourceInventory := getBackupTestInventory("test-migration-source", testProviderName)
+		targetInventory := getBackupTestInventory("test-migration-target", backupProvider.Name)
+		createdMigration := &v1alpha1.DBaaSInventoryMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-migration-provider-mismatch",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSInventoryMigrationSpec{
+				SourceInventoryRef: v1alpha1.LocalObjectReference{Name: sourceInventory.Name},
+				TargetInventoryRef: v1alpha1.LocalObjectReference{Name: targetInventory.Name},
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+		BeforeEach(assertResourceCreationIfNotExists(backupProvider))
+		BeforeEach(assertResourceCreation(sourceInventory))
+		BeforeEach(assertResourceCreation(targetInventory))
+		BeforeEach(assertResourceCreation(createdMigration))
+		AfterEach(assertResourceDeletion(createdMigration))
+		AfterEach(assertResourceDeletion(targetInventory))
+		AfterEach(assertResourceDeletion(sourceInventory))
+		It("should fail the migration", assertDBaaSResourceStatusUpdated(createdMigration, metav1.ConditionFalse, v1alpha1.DBaaSMigrationProviderMismatch))
+	})
+
+	Context("after creating DBaaSInventoryMigration with a connection to the source inventory", func() {
+		sourceInventory := getBackupTestInventory("test-migration-connection-source", testProviderName)
+		targetInventory := getBackupTestInventory("test-migration-connection-target", testProviderName)
+		connection := &v1alpha1.DBaaSConnection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-migration-connection",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSConnectionSpec{
+				InventoryRef: v1alpha1.NamespacedName{
+					Name:      sourceInventory.Name,
+					Namespace: testNamespace,
+				},
+				InstanceID: "testInstanceID",
+			},
+		}
+		createdMigration := &v1alpha1.DBaaSInventoryMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-migration-connection",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSInventoryMigrationSpec{
+				SourceInventoryRef: v1alpha1.LocalObjectReference{Name: sourceInventory.Name},
+				TargetInventoryRef: v1alpha1.LocalObjectReference{Name: targetInventory.Name},
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+		BeforeEach(assertResourceCreationWithProviderStatus(sourceInventory, metav1.ConditionTrue, testInventoryKind, getBackupTestInventoryStatus()))
+		BeforeEach(assertResourceCreationWithProviderStatus(targetInventory, metav1.ConditionTrue, testInventoryKind, getBackupTestInventoryStatus()))
+		BeforeEach(assertResourceCreation(connection))
+		BeforeEach(assertResourceCreation(createdMigration))
+		AfterEach(assertResourceDeletion(createdMigration))
+		AfterEach(assertResourceDeletion(connection))
+		AfterEach(assertResourceDeletion(targetInventory))
+		AfterEach(assertResourceDeletion(sourceInventory))
+		It("should move the connection to the target inventory", func() {
+			assertDBaaSResourceStatusUpdated(createdMigration, metav1.ConditionTrue, v1alpha1.Ready)()
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(createdMigration), createdMigration)).Should(Succeed())
+			Expect(createdMigration.Status.Phase).Should(Equal(v1alpha1.MigrationPhaseCompleted))
+			Expect(createdMigration.Status.MigratedCount).Should(Equal(int32(1)))
+
+			migrated := &v1alpha1.DBaaSConnection{}
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(connection), migrated)).Should(Succeed())
+			Expect(migrated.Spec.InventoryRef).Should(Equal(v1alpha1.NamespacedName{Name: targetInventory.Name, Namespace: testNamespace}))
+			Expect(migrated.GetAnnotations()).Should(HaveKeyWithValue(v1alpha1.MigrationAnnotation, createdMigration.Name))
+		})
+	})
+})
+
+var _ = Describe("DBaaSInventoryMigration failed instances", func() {
+	It("should find the connections to the instances that failed to migrate", func() {
+		failed := getFailedMigrationInstances([]v1alpha1.DBaaSInventoryMigrationDependent{
+			{Kind: "DBaaSInstance", Namespace: testNamespace, Name: "failed", InstanceID: "failedID", Phase: v1alpha1.MigrationDependentFailed},
+			{Kind: "DBaaSInstance", Namespace: testNamespace, Name: "migrated", InstanceID: "migratedID", Phase: v1alpha1.MigrationDependentMigrated},
+		})
+		byRef := &v1alpha1.DBaaSConnection{Spec: v1alpha1.DBaaSConnectionSpec{InstanceRef: &v1alpha1.NamespacedName{Name: "failed", Namespace: testNamespace}}}
+		instance, found := failed.find(byRef)
+		Expect(found).Should(BeTrue())
+		Expect(instance).Should(Equal(testNamespace + "/failed"))
+
+		byID := &v1alpha1.DBaaSConnection{Spec: v1alpha1.DBaaSConnectionSpec{InstanceID: "failedID"}}
+		_, found = failed.find(byID)
+		Expect(found).Should(BeTrue())
+
+		migrated := &v1alpha1.DBaaSConnection{Spec: v1alpha1.DBaaSConnectionSpec{InstanceID: "migratedID"}}
+		_, found = failed.find(migrated)
+		Expect(found).Should(BeFalse())
+	})
+})
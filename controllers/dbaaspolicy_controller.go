@@ -18,20 +18,57 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// policyRoleBindingLabel labels the RoleBindings created for the developer subjects of the DBaaSPolicies
+	policyRoleBindingLabel = "dbaas.redhat.com/policy-rolebinding"
+	// policyHashLabel labels the RoleBindings of a DBaaSPolicy with a hash of its namespace and name, which may not fit a label value
+	policyHashLabel = "dbaas.redhat.com/policy-hash"
+	// developerAccessLabel opts a namespace in to the RoleBindings of the developer subjects of the DBaaSPolicies allowing it,
+	// so that a policy cannot grant access to a namespace without the consent of its administrators
+	developerAccessLabel = "dbaas.redhat.com/developer-access"
+	// policyAnnotation references the DBaaSPolicy of a RoleBinding, as namespace/name
+	policyAnnotation = "dbaas.redhat.com/policy"
+
+	// the ClusterRoles of config/rbac bound to the developer subjects of the DBaaSPolicies
+	connectionEditorRole = "dbaas-operator-dbaasconnection-editor-role"
+	instanceEditorRole   = "dbaas-operator-dbaasinstance-editor-role"
+	inventoryViewerRole  = "dbaas-operator-dbaasinventory-viewer-role"
+
+	// maxPoliciesPerNamespace is the maximum number of DBaaSPolicies merged in a namespace, enforced by a ResourceQuota
+	maxPoliciesPerNamespace = 10
 )
 
 // DBaaSPolicyReconciler reconciles a DBaaSPolicy object
 type DBaaSPolicyReconciler struct {
 	*DBaaSReconciler
+
+	// roleBindingCache only caches the RoleBindings labelled by the reconciler
+	roleBindingCache cache.Cache
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -40,6 +77,7 @@ type DBaaSPolicyReconciler struct {
 //+kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;create;update;watch
 //+kubebuilder:rbac:groups=core,resources=resourcequotas/finalizers,verbs=update
 //+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -48,73 +86,110 @@ type DBaaSPolicyReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
 func (r *DBaaSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
+	var policy v1alpha1.DBaaSPolicy
+	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, the other policies of the namespace are reconciled by the policy watch
+			if err := r.reconcileRoleBindings(ctx, req.NamespacedName, nil); err != nil {
+				logger.Error(err, "Error deleting the RoleBindings of the deleted DBaaS Policy")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Policy for reconcile")
+		return ctrl.Result{}, err
+	}
 	policyList, err := r.policyListByNS(ctx, req.Namespace)
 	if err != nil {
 		logger.Error(err, "unable to list policies")
 		return ctrl.Result{}, err
 	}
-	activePolicy := getActivePolicy(policyList)
 
-	var policy v1alpha1.DBaaSPolicy
-	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
-		if errors.IsNotFound(err) {
-			// CR deleted since request queued
-			if len(policyList.Items) > 0 && activePolicy == nil {
-				// reconcile another policy to ensure one is active
-				policy = policyList.Items[0]
-			} else {
-				// child objects getting GC'd, no requeue
-				return ctrl.Result{}, nil
-			}
-		} else {
-			logger.Error(err, "Error fetching DBaaS Policy for reconcile")
-			return ctrl.Result{}, err
-		}
+	clusterPolicy, err := v1alpha1.GetClusterPolicy(ctx, r.Client)
+	if err != nil {
+		logger.Error(err, "Error fetching the Cluster DBaaS Policy")
+		return ctrl.Result{}, err
 	}
+
 	cond := &metav1.Condition{
 		Type:    v1alpha1.DBaaSPolicyReadyType,
 		Status:  metav1.ConditionTrue,
 		Reason:  v1alpha1.Ready,
 		Message: v1alpha1.MsgPolicyReady,
 	}
-
-	// if an active policy exists, and it's not this one... set status to false
-	if activePolicy != nil &&
-		activePolicy.GetName() != policy.Name {
-		cond = &metav1.Condition{
-			Type:    v1alpha1.DBaaSPolicyReadyType,
-			Status:  metav1.ConditionFalse,
-			Reason:  v1alpha1.DBaaSPolicyNotReady,
-			Message: v1alpha1.MsgPolicyNotReady + " - " + activePolicy.GetName(),
+	// the effective policy is merged from the Ready policies of the namespace as it is enforced, this policy included
+	found := false
+	for i := range policyList.Items {
+		if policyList.Items[i].Name == policy.Name {
+			policyList.Items[i] = *policy.DeepCopy()
+			apimeta.SetStatusCondition(&policyList.Items[i].Status.Conditions, *cond)
+			found = true
 		}
 	}
+	if !found {
+		readyPolicy := policy.DeepCopy()
+		apimeta.SetStatusCondition(&readyPolicy.Status.Conditions, *cond)
+		policyList.Items = append(policyList.Items, *readyPolicy)
+	}
+	activePolicies := v1alpha1.ActivePolicies(policyList.Items)
+	v1alpha1.SortPolicies(activePolicies)
+	policy.Status.EffectivePolicy = clusterPolicy.Apply(v1alpha1.EffectivePolicy(policyList.Items))
+	policy.Status.MergedPolicies = make([]string, 0, len(activePolicies))
+	for _, p := range activePolicies {
+		policy.Status.MergedPolicies = append(policy.Status.MergedPolicies, p.Name)
+	}
 
-	// if policy is active, create resourcequota
-	if cond.Status == metav1.ConditionTrue {
-		resQuota := v1.ResourceQuota{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "dbaas-" + policy.Name,
-				Namespace: policy.Namespace,
+	// bound the number of policies merged in the namespace
+	resQuota := v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dbaas-" + policy.Name,
+			Namespace: policy.Namespace,
+		},
+	}
+	if res, err := controllerutil.CreateOrUpdate(ctx, r.Client, &resQuota, func() error {
+		resQuota.Spec = v1.ResourceQuotaSpec{
+			Hard: v1.ResourceList{
+				v1.ResourceName("count/dbaaspolicies." + v1alpha1.GroupVersion.Group): *resource.NewQuantity(maxPoliciesPerNamespace, resource.DecimalSI),
 			},
 		}
-		if res, err := controllerutil.CreateOrUpdate(ctx, r.Client, &resQuota, func() error {
-			resQuota.Spec = v1.ResourceQuotaSpec{
-				Hard: v1.ResourceList{
-					v1.ResourceName("count/dbaaspolicies." + v1alpha1.GroupVersion.Group): resource.MustParse("1"),
-				},
-			}
-			resQuota.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("ResourceQuota"))
-			return ctrl.SetControllerReference(&policy, &resQuota, r.Scheme)
-		}); err != nil {
-			if errors.IsConflict(err) {
-				logger.V(1).Info("ResourceQuota resource modified, retry syncing status", "ResourceQuota", resQuota)
-				return ctrl.Result{Requeue: true}, err
-			}
-			logger.Error(err, "Error updating the ResourceQuota resource status", "ResourceQuota", resQuota)
-			return ctrl.Result{}, err
-		} else if res != controllerutil.OperationResultNone {
-			logger.Info("ResourceQuota resource reconciled", "ResourceQuota", resQuota, "result", res)
+		resQuota.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("ResourceQuota"))
+		return ctrl.SetControllerReference(&policy, &resQuota, r.Scheme)
+	}); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("ResourceQuota resource modified, retry syncing status", "ResourceQuota", resQuota)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the ResourceQuota resource status", "ResourceQuota", resQuota)
+		return ctrl.Result{}, err
+	} else if res != controllerutil.OperationResultNone {
+		logger.Info("ResourceQuota resource reconciled", "ResourceQuota", resQuota, "result", res)
+	}
+
+	allowedNamespaces, err := r.listAllowedNamespaces(ctx, policy.Namespace, policy.Status.EffectivePolicy, clusterPolicy)
+	if err != nil {
+		logger.Error(err, "Error listing the namespaces allowed by the DBaaS Policy", "DBaaS Policy", policy)
+		return ctrl.Result{}, err
+	}
+	policy.Status.AllowedNamespaces = v1alpha1.NewAllowedNamespaces(allowedNamespaces, policy.Status.EffectivePolicy)
+
+	usage, err := r.getNamespaceUsage(ctx, policy.Namespace, policy.Status.EffectivePolicy, allowedNamespaces)
+	if err != nil {
+		logger.Error(err, "Error counting the DBaaS resources referencing the policy's inventories", "DBaaS Policy", policy)
+		return ctrl.Result{}, err
+	}
+	policy.Status.NamespaceUsage = usage
+
+	developerNamespaces, err := r.listDeveloperNamespaces(ctx, allowedNamespaces)
+	if err != nil {
+		logger.Error(err, "Error listing the namespaces opted in to the developer RoleBindings", "DBaaS Policy", policy)
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileRoleBindings(ctx, client.ObjectKeyFromObject(&policy), getDeveloperRoleBindings(&policy, developerNamespaces)); err != nil {
+		if errors.IsConflict(err) || errors.IsAlreadyExists(err) {
+			return ctrl.Result{Requeue: true}, nil
 		}
+		logger.Error(err, "Error syncing the RoleBindings of the developer subjects of the DBaaS Policy", "DBaaS Policy", policy)
+		return ctrl.Result{}, err
 	}
 
 	return r.updateStatusCondition(ctx, policy, cond)
@@ -122,12 +197,268 @@ func (r *DBaaSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	// only cache the RoleBindings created for the developer subjects
+	roleBindingCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+		SelectorsByObject: cache.SelectorsByObject{
+			&rbacv1.RoleBinding{}: {Label: labels.SelectorFromSet(labels.Set{policyRoleBindingLabel: "true"})},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(roleBindingCache); err != nil {
+		return err
+	}
+	r.roleBindingCache = roleBindingCache
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBaaSPolicy{}).
 		Owns(&v1.ResourceQuota{}).
+		// the effective policy changes with the spec of the policies, and when they become Ready
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.policyMapFn),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, policyReadyChangedPredicate))).
+		Watches(&source.Kind{Type: &v1alpha1.ClusterDBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.clusterPolicyMapFn),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.dependentMapFn)).
+		Watches(&source.Kind{Type: &v1alpha1.DBaaSConnection{}}, handler.EnqueueRequestsFromMapFunc(r.dependentMapFn)).
+		Watches(source.NewKindWithCache(&rbacv1.RoleBinding{}, roleBindingCache), handler.EnqueueRequestsFromMapFunc(roleBindingMapFn))
+	return watchNamespaces(b, r.namespaceMapFn).
 		Complete(r)
 }
 
+// roleBindingMapFn maps a RoleBinding created for the developer subjects to its DBaaSPolicy,
+// so that modified RoleBindings are restored, and those of deleted policies are removed
+func roleBindingMapFn(o client.Object) []reconcile.Request {
+	policyKey := strings.SplitN(o.GetAnnotations()[policyAnnotation], "/", 2)
+	if len(policyKey) != 2 {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: policyKey[0], Name: policyKey[1]}}}
+}
+
+// policyMapFn maps a DBaaSPolicy to all the DBaaSPolicies of its namespace, which share the effective policy
+func (r *DBaaSPolicyReconciler) policyMapFn(o client.Object) []reconcile.Request {
+	return r.policyRequests(context.Background(), o.GetNamespace())
+}
+
+// clusterPolicyMapFn maps the ClusterDBaaSPolicy to all the DBaaSPolicies, whose effective policy it bounds
+func (r *DBaaSPolicyReconciler) clusterPolicyMapFn(_ client.Object) []reconcile.Request {
+	return r.policyRequests(context.Background(), "")
+}
+
+// namespaceMapFn maps a created, deleted or relabeled namespace to the DBaaSPolicies whose allowed namespaces it may change
+func (r *DBaaSPolicyReconciler) namespaceMapFn(o client.Object) []reconcile.Request {
+	policyList, err := r.policyListByNS(context.Background(), "")
+	if err != nil {
+		ctrl.Log.WithName("DBaaSPolicyReconciler").Error(err, "unable to list policies")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range policyList.Items {
+		if policyMayAllowNamespace(&policyList.Items[i], o) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policyList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// policyMayAllowNamespace checks whether a namespace is, or was at the last reconcile, allowed by a policy.
+// a namespace that was allowed is reported in the policy status, unless the allowed namespaces are not all listed.
+func policyMayAllowNamespace(policy *v1alpha1.DBaaSPolicy, ns client.Object) bool {
+	allowed := policy.Status.AllowedNamespaces
+	if policy.Namespace == ns.GetName() || allowed == nil || allowed.AllNamespaces || int(allowed.Count) > len(allowed.Namespaces) {
+		return true
+	}
+	for _, name := range allowed.Namespaces {
+		if name == ns.GetName() {
+			return true
+		}
+	}
+	ok, err := policy.Status.EffectivePolicy.AllowsConnectionNamespace(ns.GetName(), ns.GetLabels())
+	return ok || err != nil
+}
+
+// dependentMapFn maps a DBaaSConnection or DBaaSInstance to the DBaaSPolicies of its inventory's namespace
+func (r *DBaaSPolicyReconciler) dependentMapFn(o client.Object) []reconcile.Request {
+	ctx := context.Background()
+	inventoryRef, err := v1alpha1.ResolveInventoryRef(ctx, r.Client, o)
+	if err != nil || inventoryRef == nil {
+		return nil
+	}
+	return r.policyRequests(ctx, inventoryRef.Namespace)
+}
+
+func (r *DBaaSPolicyReconciler) policyRequests(ctx context.Context, namespace string) []reconcile.Request {
+	policyList, err := r.policyListByNS(ctx, namespace)
+	if err != nil {
+		ctrl.Log.WithName("DBaaSPolicyReconciler").Error(err, "unable to list policies", "Namespace", namespace)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policyList.Items))
+	for i := range policyList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policyList.Items[i])})
+	}
+	return requests
+}
+
+// getNamespaceUsage counts the instances and connections each allowed namespace holds against the inventories of the policy's namespace,
+// listing the dependents of each inventory with the inventoryRef indexes
+func (r *DBaaSPolicyReconciler) getNamespaceUsage(ctx context.Context, policyNamespace string, policy *v1alpha1.DBaaSInventoryPolicy,
+	namespaces []string) ([]v1alpha1.DBaaSNamespaceUsage, error) {
+	var inventoryList v1alpha1.DBaaSInventoryList
+	if err := r.List(ctx, &inventoryList, client.InNamespace(policyNamespace)); err != nil {
+		return nil, err
+	}
+	instances, connections := map[string]int32{}, map[string]int32{}
+	for i := range inventoryList.Items {
+		connectionList, instanceList, err := r.listInventoryDependents(ctx, &inventoryList.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instanceList.Items {
+			instances[instance.Namespace]++
+		}
+		for _, connection := range connectionList.Items {
+			connections[connection.Namespace]++
+		}
+	}
+
+	usage := []v1alpha1.DBaaSNamespaceUsage{}
+	for _, namespace := range namespaces {
+		if instances[namespace] > 0 || connections[namespace] > 0 {
+			usage = append(usage, v1alpha1.DBaaSNamespaceUsage{
+				Namespace:      namespace,
+				Instances:      instances[namespace],
+				MaxInstances:   policy.MaxInstancesPerNamespace,
+				Connections:    connections[namespace],
+				MaxConnections: policy.MaxConnectionsPerNamespace,
+			})
+		}
+	}
+	return usage, nil
+}
+
+// listDeveloperNamespaces returns the allowed namespaces opted in to the RoleBindings of the developer subjects
+func (r *DBaaSPolicyReconciler) listDeveloperNamespaces(ctx context.Context, allowedNamespaces []string) ([]string, error) {
+	var namespaceList v1.NamespaceList
+	if err := r.List(ctx, &namespaceList, client.MatchingLabels{developerAccessLabel: "true"}); err != nil {
+		return nil, err
+	}
+	optedIn := make(map[string]bool, len(namespaceList.Items))
+	for _, ns := range namespaceList.Items {
+		optedIn[ns.Name] = true
+	}
+	var namespaces []string
+	for _, ns := range allowedNamespaces {
+		if optedIn[ns] {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
+}
+
+// policyHash returns a hash of the namespace and name of a policy, short enough for the names and labels of its RoleBindings
+func policyHash(policyKey types.NamespacedName) string {
+	h := fnv.New64a()
+	h.Write([]byte(policyKey.String()))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// developerRoleBindingName returns the name of a RoleBinding of the developer subjects of a policy
+func developerRoleBindingName(policyKey types.NamespacedName, suffix string) string {
+	return fmt.Sprintf("dbaas-policy-%s-%s", policyHash(policyKey), suffix)
+}
+
+// getDeveloperRoleBindings returns the RoleBindings granting the developer subjects of a policy the DBaaSConnection
+// and DBaaSInstance editor roles in the other namespaces, and the DBaaSInventory viewer role in the policy's namespace,
+// if they are part of the namespaces opted in to the developer RoleBindings
+func getDeveloperRoleBindings(policy *v1alpha1.DBaaSPolicy, developerNamespaces []string) []rbacv1.RoleBinding {
+	if len(policy.Spec.DeveloperSubjects) == 0 || policy.DeletionTimestamp != nil {
+		return nil
+	}
+	subjects := make([]rbacv1.Subject, 0, len(policy.Spec.DeveloperSubjects))
+	for _, subject := range policy.Spec.DeveloperSubjects {
+		subjects = append(subjects, rbacv1.Subject{
+			APIGroup: rbacv1.GroupName,
+			Kind:     subject.Kind,
+			Name:     subject.Name,
+		})
+	}
+	policyKey := client.ObjectKeyFromObject(policy)
+	newRoleBinding := func(namespace, suffix, clusterRole string) rbacv1.RoleBinding {
+		return rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        developerRoleBindingName(policyKey, suffix),
+				Namespace:   namespace,
+				Labels:      map[string]string{policyRoleBindingLabel: "true", policyHashLabel: policyHash(policyKey)},
+				Annotations: map[string]string{policyAnnotation: policyKey.String()},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     clusterRole,
+			},
+			Subjects: subjects,
+		}
+	}
+	var roleBindings []rbacv1.RoleBinding
+	for _, namespace := range developerNamespaces {
+		if namespace == policy.Namespace {
+			roleBindings = append(roleBindings, newRoleBinding(namespace, "inventory-viewer", inventoryViewerRole))
+			continue
+		}
+		roleBindings = append(roleBindings,
+			newRoleBinding(namespace, "connection-editor", connectionEditorRole),
+			newRoleBinding(namespace, "instance-editor", instanceEditorRole))
+	}
+	return roleBindings
+}
+
+// reconcileRoleBindings creates or updates the RoleBindings of a policy, and deletes those it no longer needs
+func (r *DBaaSPolicyReconciler) reconcileRoleBindings(ctx context.Context, policyKey types.NamespacedName, roleBindings []rbacv1.RoleBinding) error {
+	logger := ctrl.LoggerFrom(ctx)
+	var roleBindingList rbacv1.RoleBindingList
+	if err := r.roleBindingCache.List(ctx, &roleBindingList, client.MatchingLabels{policyHashLabel: policyHash(policyKey)}); err != nil {
+		return err
+	}
+	existing := map[types.NamespacedName]*rbacv1.RoleBinding{}
+	for i := range roleBindingList.Items {
+		existing[client.ObjectKeyFromObject(&roleBindingList.Items[i])] = &roleBindingList.Items[i]
+	}
+
+	for i := range roleBindings {
+		roleBinding := &roleBindings[i]
+		key := client.ObjectKeyFromObject(roleBinding)
+		current, ok := existing[key]
+		delete(existing, key)
+		if !ok {
+			if err := r.Client.Create(ctx, roleBinding); err != nil {
+				return err
+			}
+			logger.Info("RoleBinding created for the developer subjects of the DBaaS Policy", "RoleBinding", key)
+			continue
+		}
+		// the role is part of the name, only the subjects change
+		if reflect.DeepEqual(current.Subjects, roleBinding.Subjects) {
+			continue
+		}
+		current.Subjects = roleBinding.Subjects
+		if err := r.Client.Update(ctx, current); err != nil {
+			return err
+		}
+	}
+
+	for key, roleBinding := range existing {
+		if err := r.Client.Delete(ctx, roleBinding); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		logger.Info("RoleBinding of the DBaaS Policy deleted", "RoleBinding", key)
+	}
+	return nil
+}
+
 func (r *DBaaSPolicyReconciler) updateStatusCondition(ctx context.Context, policy v1alpha1.DBaaSPolicy, cond *metav1.Condition) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	apimeta.SetStatusCondition(&policy.Status.Conditions, *cond)
@@ -141,13 +472,3 @@ func (r *DBaaSPolicyReconciler) updateStatusCondition(ctx context.Context, polic
 	}
 	return ctrl.Result{}, nil
 }
-
-// get active policy, return nil if none exists
-func getActivePolicy(policyList v1alpha1.DBaaSPolicyList) *v1alpha1.DBaaSPolicy {
-	for i := range policyList.Items {
-		if apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1alpha1.DBaaSPolicyReadyType) {
-			return &policyList.Items[i]
-		}
-	}
-	return nil
-}
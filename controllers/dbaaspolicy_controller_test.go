@@ -21,6 +21,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -30,19 +34,116 @@ var _ = Describe("DBaaSPolicy controller", func() {
 	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
 
 	Describe("reconcile", func() {
-		Context("w/ status NotReady", func() {
+		Context("w/ multiple policies", func() {
 			policy2 := getDefaultPolicy(testNamespace)
 			policy2.Name = "test"
+			policy2.Spec.Priority = 10
+			maxInstances := int32(5)
+			policy2.Spec.MaxInstancesPerNamespace = &maxInstances
+			policy2.Spec.ConnectionNamespaces = &[]string{"test-namespace-app"}
 			BeforeEach(assertResourceCreationIfNotExists(&policy2))
-			BeforeEach(assertDBaaSResourceStatusUpdated(&policy2, metav1.ConditionFalse, v1alpha1.DBaaSPolicyNotReady))
+			BeforeEach(assertDBaaSResourceStatusUpdated(&policy2, metav1.ConditionTrue, v1alpha1.Ready))
+			AfterEach(assertResourceDeletion(&policy2))
 
-			It("should return second policy with existing policy name in status message", func() {
+			It("should make both policies active and merge them by priority", func() {
 				getPolicy := v1alpha1.DBaaSPolicy{}
-				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy2), &getPolicy)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(getPolicy.Status.Conditions).Should(HaveLen(1))
-				Expect(getPolicy.Status.Conditions[0].Message).Should(Equal(v1alpha1.MsgPolicyNotReady + " - " + defaultPolicy.GetName()))
+				Eventually(func() ([]string, error) {
+					err := dRec.Get(ctx, client.ObjectKeyFromObject(&defaultPolicy), &getPolicy)
+					return getPolicy.Status.MergedPolicies, err
+				}, timeout).Should(Equal([]string{policy2.Name, defaultPolicy.Name}))
+				Expect(apimeta.IsStatusConditionTrue(getPolicy.Status.Conditions, v1alpha1.DBaaSPolicyReadyType)).Should(BeTrue())
+				Expect(getPolicy.Status.EffectivePolicy).Should(Equal(&v1alpha1.DBaaSInventoryPolicy{
+					ConnectionNamespaces:     &[]string{"test-namespace-app"},
+					MaxInstancesPerNamespace: &maxInstances,
+				}))
+			})
+
+			It("should only report the namespaces of the highest priority policy as allowed", func() {
+				Eventually(func() (*v1alpha1.DBaaSAllowedNamespaces, error) {
+					getPolicy := v1alpha1.DBaaSPolicy{}
+					err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy2), &getPolicy)
+					return getPolicy.Status.AllowedNamespaces, err
+				}, timeout).Should(Equal(&v1alpha1.DBaaSAllowedNamespaces{
+					Namespaces: []string{testNamespace},
+					Count:      1,
+				}))
 			})
 		})
+
+		Context("w/ developer subjects", func() {
+			devNS := &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-policy-dev",
+					Labels: map[string]string{developerAccessLabel: "true"},
+				},
+			}
+			otherNS := &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-policy-not-opted-in",
+				},
+			}
+			policy3 := getDefaultPolicy(testNamespace)
+			policy3.Name = "test-developers"
+			policy3.Spec.DeveloperSubjects = []v1alpha1.DBaaSPolicySubject{
+				{Kind: rbacv1.GroupKind, Name: "developers"},
+			}
+			BeforeEach(assertResourceCreationIfNotExists(devNS))
+			BeforeEach(assertResourceCreationIfNotExists(otherNS))
+			BeforeEach(assertResourceCreationIfNotExists(&policy3))
+			BeforeEach(assertDBaaSResourceStatusUpdated(&policy3, metav1.ConditionTrue, v1alpha1.Ready))
+
+			It("should bind the developer subjects in the opted in namespaces until the policy is deleted", func() {
+				policyKey := client.ObjectKeyFromObject(&policy3)
+				roleBindingKeys := []client.ObjectKey{
+					{Namespace: devNS.Name, Name: developerRoleBindingName(policyKey, "connection-editor")},
+					{Namespace: devNS.Name, Name: developerRoleBindingName(policyKey, "instance-editor")},
+				}
+				for _, key := range roleBindingKeys {
+					roleBinding := &rbacv1.RoleBinding{}
+					Eventually(func() error {
+						return dRec.Get(ctx, key, roleBinding)
+					}, timeout).Should(Succeed())
+					Expect(roleBinding.Subjects).Should(Equal([]rbacv1.Subject{
+						{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "developers"},
+					}))
+					Expect(roleBinding.Labels).Should(HaveKeyWithValue(policyHashLabel, policyHash(policyKey)))
+				}
+
+				By("not binding the namespaces that did not opt in")
+				roleBindingList := &rbacv1.RoleBindingList{}
+				Expect(dRec.List(ctx, roleBindingList, client.MatchingLabels{policyHashLabel: policyHash(policyKey)})).Should(Succeed())
+				Expect(roleBindingList.Items).Should(HaveLen(len(roleBindingKeys)))
+
+				assertResourceDeletion(&policy3)()
+				for _, key := range roleBindingKeys {
+					Eventually(func() bool {
+						return errors.IsNotFound(dRec.Get(ctx, key, &rbacv1.RoleBinding{}))
+					}, timeout).Should(BeTrue())
+				}
+			})
+		})
+	})
+})
+
+var _ = Describe("DBaaSPolicy namespace mapping", func() {
+	It("should only map the namespaces a policy allows or allowed", func() {
+		policy := &v1alpha1.DBaaSPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test-mapping", Namespace: testNamespace}}
+		allowedNS := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "mapping-allowed"}}
+		selectedNS := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "mapping-selected", Labels: map[string]string{"dbaas-test": "mapping"}}}
+		otherNS := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "mapping-other"}}
+		Expect(policyMayAllowNamespace(policy, otherNS)).Should(BeTrue())
+
+		policy.Status.EffectivePolicy = &v1alpha1.DBaaSInventoryPolicy{
+			ConnectionNsSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dbaas-test": "mapping"}},
+		}
+		policy.Status.AllowedNamespaces = v1alpha1.NewAllowedNamespaces([]string{testNamespace, allowedNS.Name}, policy.Status.EffectivePolicy)
+		Expect(policyMayAllowNamespace(policy, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})).Should(BeTrue())
+		Expect(policyMayAllowNamespace(policy, allowedNS)).Should(BeTrue())
+		Expect(policyMayAllowNamespace(policy, selectedNS)).Should(BeTrue())
+		Expect(policyMayAllowNamespace(policy, otherNS)).Should(BeFalse())
+
+		By("mapping all the namespaces for the \"*\" wildcard")
+		policy.Status.AllowedNamespaces.AllNamespaces = true
+		Expect(policyMayAllowNamespace(policy, otherNS)).Should(BeTrue())
 	})
 })
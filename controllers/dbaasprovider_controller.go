@@ -35,6 +35,8 @@ type DBaaSProviderReconciler struct {
 	ConnectionCtrl controller.Controller
 	InventoryCtrl  controller.Controller
 	InstanceCtrl   controller.Controller
+	BackupCtrl     controller.Controller
+	RestoreCtrl    controller.Controller
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -78,6 +80,22 @@ func (r *DBaaSProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 	logger.Info("Watching Provider Instance CR", "Kind", provider.Spec.InstanceKind)
 
+	if len(provider.Spec.BackupKind) > 0 {
+		if err := r.watchDBaaSProviderObject(r.BackupCtrl, &v1alpha1.DBaaSBackup{}, provider.Spec.BackupKind); err != nil {
+			logger.Error(err, "Error watching Provider Backup CR", "Kind", provider.Spec.BackupKind)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Watching Provider Backup CR", "Kind", provider.Spec.BackupKind)
+	}
+
+	if len(provider.Spec.RestoreKind) > 0 {
+		if err := r.watchDBaaSProviderObject(r.RestoreCtrl, &v1alpha1.DBaaSRestore{}, provider.Spec.RestoreKind); err != nil {
+			logger.Error(err, "Error watching Provider Restore CR", "Kind", provider.Spec.RestoreKind)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Watching Provider Restore CR", "Kind", provider.Spec.RestoreKind)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -0,0 +1,107 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// DBaaSRestoreReconciler reconciles a DBaaSRestore object
+type DBaaSRestoreReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
+func (r *DBaaSRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var restore v1alpha1.DBaaSRestore
+	if err := r.Get(ctx, req.NamespacedName, &restore); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("DBaaS Restore resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Restore for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	setStatusError := func(reason string, message string) {
+		cond := metav1.Condition{
+			Type:    v1alpha1.DBaaSRestoreReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}
+		apimeta.SetStatusCondition(&restore.Status.Conditions, cond)
+		restore.Status.Phase = v1alpha1.RestorePhaseError
+	}
+
+	return r.reconcileProviderOperation(ctx, restore.Spec.InventoryRef, &restore, setStatusError,
+		func(provider *v1alpha1.DBaaSProvider) string {
+			return provider.Spec.RestoreKind
+		},
+		v1alpha1.DBaaSRestoreNotSupported, v1alpha1.MsgRestoreNotSupported,
+		func() interface{} {
+			return restore.Spec.DeepCopy()
+		},
+		func() interface{} {
+			return &v1alpha1.DBaaSProviderRestore{}
+		},
+		func(i interface{}) metav1.Condition {
+			providerRestore := i.(*v1alpha1.DBaaSProviderRestore)
+			return mergeRestoreStatus(&restore, providerRestore)
+		},
+		func() *[]metav1.Condition {
+			return &restore.Status.Conditions
+		},
+		v1alpha1.DBaaSRestoreReadyType,
+		logger,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSRestoreReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBaaSRestore{}).
+		Build(r)
+}
+
+// mergeRestoreStatus: merge the status from DBaaSProviderRestore into the current DBaaSRestore status
+func mergeRestoreStatus(restore *v1alpha1.DBaaSRestore, providerRestore *v1alpha1.DBaaSProviderRestore) metav1.Condition {
+	providerRestore.Status.DeepCopyInto(&restore.Status)
+	if len(restore.Status.Phase) == 0 {
+		restore.Status.Phase = v1alpha1.RestorePhaseUnknown
+	}
+	return providerSyncCondition(providerRestore.Status.Conditions, v1alpha1.DBaaSRestoreProviderSyncType, v1alpha1.DBaaSRestoreReadyType)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+var _ = Describe("DBaaSRestore controller", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1alpha1.Ready))
+
+	Context("after creating DBaaSRestore against a provider without restore support", func() {
+		createdDBaaSInventory := getBackupTestInventory("test-restore-inventory-unsupported", testProviderName)
+		createdDBaaSRestore := &v1alpha1.DBaaSRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-restore-unsupported",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSRestoreSpec{
+				InventoryRef: v1alpha1.NamespacedName{
+					Name:      createdDBaaSInventory.Name,
+					Namespace: testNamespace,
+				},
+				BackupID:   "testBackupID",
+				InstanceID: "testInstanceID",
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, getBackupTestInventoryStatus()))
+		BeforeEach(assertResourceCreation(createdDBaaSRestore))
+		AfterEach(assertResourceDeletion(createdDBaaSRestore))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSRestore, metav1.ConditionFalse, v1alpha1.DBaaSRestoreNotSupported))
+	})
+
+	Context("after creating DBaaSRestore against a provider with restore support", func() {
+		createdDBaaSInventory := getBackupTestInventory("test-restore-inventory", backupProvider.Name)
+		DBaaSRestoreSpec := &v1alpha1.DBaaSRestoreSpec{
+			InventoryRef: v1alpha1.NamespacedName{
+				Name:      createdDBaaSInventory.Name,
+				Namespace: testNamespace,
+			},
+			BackupID:   "testBackupID",
+			InstanceID: "testInstanceID",
+		}
+		createdDBaaSRestore := &v1alpha1.DBaaSRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-restore",
+				Namespace: testNamespace,
+			},
+			Spec: *DBaaSRestoreSpec,
+		}
+		BeforeEach(assertResourceCreationIfNotExists(backupProvider))
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, getBackupTestInventoryStatus()))
+		BeforeEach(assertResourceCreation(createdDBaaSRestore))
+		AfterEach(assertResourceDeletion(createdDBaaSRestore))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		It("should create a provider restore", assertProviderResourceCreated(createdDBaaSRestore, testRestoreKind, DBaaSRestoreSpec))
+	})
+})
@@ -20,6 +20,7 @@ const (
 	metricNameDBaasConnectionDuration             = "dbaas_connection_request_duration_seconds"
 	metricNameInstanceStatusReady                 = "dbaas_instance_status_ready"
 	metricNameDBaasInstanceDuration               = "dbaas_instance_request_duration_seconds"
+	metricNameInstancePhaseDuration               = "dbaas_instance_phase_duration_seconds"
 	metricNameInstancePhase                       = "dbaas_instance_phase"
 	metricNameOperatorVersion                     = "dbaas_version_info"
 
@@ -34,6 +35,7 @@ const (
 	metricLabelInstanceID        = "instance_id"
 	metricLabelReason            = "reason"
 	metricLabelInstanceName      = "name"
+	metricLabelPhase             = "phase"
 	metricLabelCreationTimestamp = "creation_timestamp"
 	metricLabelConsoleULR        = "openshift_url"
 	metricLabelPlatformName      = "cloud_platform_name"
@@ -108,11 +110,18 @@ var DBaasConnectionRequestDurationSeconds = prometheus.NewHistogramVec(prometheu
 }, []string{metricLabelProvider, metricLabelAccountName, metricLabelInstanceID, metricLabelConnectionName, metricLabelNameSpace, metricLabelCreationTimestamp})
 
 // DBaasInstanceRequestDurationSeconds defines a histogram for DBaasInstanceRequestDuration
+// Deprecated: superseded by DBaaSInstancePhaseDurationSeconds, kept for one release so existing dashboards keep working
 var DBaasInstanceRequestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Name: metricNameDBaasInstanceDuration,
-	Help: "Request/Response duration of instance of upstream calls to provider operator/service endpoints",
+	Help: "Request/Response duration of instance of upstream calls to provider operator/service endpoints (deprecated, use dbaas_instance_phase_duration_seconds)",
 }, []string{metricLabelProvider, metricLabelAccountName, metricLabelInstanceName, metricLabelNameSpace, metricLabelCreationTimestamp})
 
+// DBaaSInstancePhaseDurationSeconds defines a gauge for the time an instance spent in each phase
+var DBaaSInstancePhaseDurationSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: metricNameInstancePhaseDuration,
+	Help: "Time in seconds the instance spent in each phase of its phase history, the current phase is counted up to the last reconcile",
+}, []string{metricLabelProvider, metricLabelAccountName, metricLabelInstanceName, metricLabelNameSpace, metricLabelPhase, metricLabelCreationTimestamp})
+
 // Execution tracks state for an API execution for emitting metrics
 type Execution struct {
 	begin time.Time
@@ -283,6 +292,7 @@ func SetInstanceMetrics(provider string, account string, instance dbaasv1alpha1.
 	setInstanceStatusMetrics(provider, account, instance)
 	setInstancePhaseMetrics(provider, account, instance)
 	setInstanceRequestDurationSeconds(provider, account, instance, execution)
+	setInstancePhaseDurationSeconds(provider, account, instance)
 
 }
 
@@ -318,6 +328,23 @@ func setInstanceRequestDurationSeconds(provider string, account string, instance
 	}
 }
 
+// setInstancePhaseDurationSeconds set the metrics for the time spent in each phase, based on the instance phase history
+func setInstancePhaseDurationSeconds(provider string, account string, instance dbaasv1alpha1.DBaaSInstance) {
+	durations := map[dbaasv1alpha1.DBaasInstancePhase]float64{}
+	history := instance.Status.PhaseHistory
+	for i, transition := range history {
+		end := time.Now()
+		if i+1 < len(history) {
+			end = history[i+1].TransitionTime.Time
+		}
+		durations[transition.Phase] += end.Sub(transition.TransitionTime.Time).Seconds()
+	}
+	DBaaSInstancePhaseDurationSeconds.DeletePartialMatch(prometheus.Labels{metricLabelInstanceName: instance.GetName(), metricLabelNameSpace: instance.GetNamespace()})
+	for phase, duration := range durations {
+		DBaaSInstancePhaseDurationSeconds.With(prometheus.Labels{metricLabelProvider: provider, metricLabelAccountName: account, metricLabelInstanceName: instance.GetName(), metricLabelNameSpace: instance.GetNamespace(), metricLabelPhase: string(phase), metricLabelCreationTimestamp: instance.CreationTimestamp.String()}).Set(duration)
+	}
+}
+
 // setInstancePhaseMetrics set the metrics for instance phase
 func setInstancePhaseMetrics(provider string, account string, instance dbaasv1alpha1.DBaaSInstance) {
 	var phase float64
@@ -361,4 +388,5 @@ func CleanInstanceMetrics(instance *dbaasv1alpha1.DBaaSInstance) {
 			DBaasInstanceRequestDurationSeconds.DeletePartialMatch(prometheus.Labels{metricLabelInstanceName: instance.GetName(), metricLabelNameSpace: instance.GetNamespace()})
 		}
 	}
+	DBaaSInstancePhaseDurationSeconds.DeletePartialMatch(prometheus.Labels{metricLabelInstanceName: instance.GetName(), metricLabelNameSpace: instance.GetNamespace()})
 }
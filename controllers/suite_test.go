@@ -51,6 +51,8 @@ var dRec *DBaaSReconciler
 var iCtrl *spyctrl
 var cCtrl *spyctrl
 var inCtrl *spyctrl
+var bCtrl *spyctrl
+var rCtrl *spyctrl
 
 const (
 	testNamespace = "default"
@@ -119,6 +121,14 @@ var _ = BeforeSuite(func() {
 		DBaaSReconciler: dRec,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
+	err = (&DBaaSInventoryMigrationReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+	err = (&DBaaSInventoryAccessReviewReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
 	inventoryCtrl, err := (&DBaaSInventoryReconciler{
 		DBaaSReconciler: dRec,
 	}).SetupWithManager(k8sManager)
@@ -131,6 +141,17 @@ var _ = BeforeSuite(func() {
 
 	instanceCtrl, err := (&DBaaSInstanceReconciler{
 		DBaaSReconciler: dRec,
+		Recorder:        k8sManager.GetEventRecorderFor("dbaasinstance-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	backupCtrl, err := (&DBaaSBackupReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	restoreCtrl, err := (&DBaaSRestoreReconciler{
+		DBaaSReconciler: dRec,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -142,12 +163,16 @@ var _ = BeforeSuite(func() {
 	iCtrl = newSpyController(inventoryCtrl)
 	cCtrl = newSpyController(connectionCtrl)
 	inCtrl = newSpyController(instanceCtrl)
+	bCtrl = newSpyController(backupCtrl)
+	rCtrl = newSpyController(restoreCtrl)
 
 	err = (&DBaaSProviderReconciler{
 		DBaaSReconciler: dRec,
 		InventoryCtrl:   iCtrl,
 		ConnectionCtrl:  cCtrl,
 		InstanceCtrl:    inCtrl,
+		BackupCtrl:      bCtrl,
+		RestoreCtrl:     rCtrl,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
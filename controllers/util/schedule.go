@@ -0,0 +1,174 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleLookupYears bounds the search for the previous or next activation of a schedule. ParseSchedule rejects
+// the schedules that never activate, the others activate at least once every 8 years (29th of February).
+const scheduleLookupYears = 9
+
+// maximum number of days of each month, in a leap year
+var daysInMonth = []int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// Schedule is a parsed cron-style schedule with the standard five fields:
+// minute, hour, day of month, month and day of week. Times are evaluated in UTC.
+type Schedule struct {
+	minute, hour, dom, month, dow []bool
+	domAny, dowAny                bool
+}
+
+// ParseSchedule parses a cron-style schedule. Each field accepts "*", values, ranges ("1-5"),
+// lists ("1,3,5") and steps ("*/15", "0-30/10").
+func ParseSchedule(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q must have 5 fields, found %d", spec, len(fields))
+	}
+	s := &Schedule{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	var err error
+	if s.minute, err = parseScheduleField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseScheduleField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseScheduleField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseScheduleField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseScheduleField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	// both 0 and 7 are sunday
+	if s.dow[7] {
+		s.dow[0] = true
+	}
+	if !s.activates() {
+		return nil, fmt.Errorf("schedule %q never activates", spec)
+	}
+	return s, nil
+}
+
+// activates checks whether one of the days of month of the schedule exists in one of its months,
+// a restricted day of week always activates
+func (s *Schedule) activates() bool {
+	if !s.dowAny {
+		return true
+	}
+	for month := 1; month <= 12; month++ {
+		if !s.month[month] {
+			continue
+		}
+		for day := 1; day <= daysInMonth[month]; day++ {
+			if s.dom[day] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseScheduleField(field string, min, max int) ([]bool, error) {
+	values := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in schedule field %q", field)
+			}
+			part = part[:i]
+		}
+		low, high := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid value in schedule field %q", field)
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid range in schedule field %q", field)
+				}
+			}
+		}
+		if low < min || high > max || low > high {
+			return nil, fmt.Errorf("schedule field %q out of range %d-%d", field, min, max)
+		}
+		for v := low; v <= high; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// matchesDay checks whether the schedule activates on the day of the given time
+func (s *Schedule) matchesDay(t time.Time) bool {
+	if !s.month[int(t.Month())] {
+		return false
+	}
+	// as in cron, a restricted day of month or day of week is enough to match
+	domMatch, dowMatch := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// Prev returns the latest activation of the schedule at or before t, skipping the days and hours
+// that do not match. returns the zero time if the schedule never activates.
+func (s *Schedule) Prev(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute)
+	for limit := t.Year() - scheduleLookupYears; t.Year() >= limit; {
+		switch {
+		case !s.matchesDay(t):
+			// last minute of the previous day
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Add(-time.Minute)
+		case !s.hour[t.Hour()]:
+			// last minute of the previous hour
+			t = t.Truncate(time.Hour).Add(-time.Minute)
+		case !s.minute[t.Minute()]:
+			t = t.Add(-time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Next returns the earliest activation of the schedule after t, skipping the days and hours
+// that do not match. returns the zero time if the schedule never activates.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	for limit := t.Year() + scheduleLookupYears; t.Year() <= limit; {
+		switch {
+		case !s.matchesDay(t):
+			// first minute of the next day
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.hour[t.Hour()]:
+			// first minute of the next hour
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseSchedule", func() {
+	It("should reject invalid schedules", func() {
+		for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "0 0 30 2 *"} {
+			_, err := ParseSchedule(spec)
+			Expect(err).Should(HaveOccurred(), spec)
+		}
+	})
+
+	It("should find the previous and next activations", func() {
+		// pause on weeknights at 20:00
+		s, err := ParseSchedule("0 20 * * 1-5")
+		Expect(err).ShouldNot(HaveOccurred())
+		// Saturday
+		now := time.Date(2022, time.May, 7, 10, 30, 0, 0, time.UTC)
+		Expect(s.Prev(now)).Should(Equal(time.Date(2022, time.May, 6, 20, 0, 0, 0, time.UTC)))
+		Expect(s.Next(now)).Should(Equal(time.Date(2022, time.May, 9, 20, 0, 0, 0, time.UTC)))
+	})
+
+	It("should support lists and steps", func() {
+		s, err := ParseSchedule("*/20 8,18 1 * *")
+		Expect(err).ShouldNot(HaveOccurred())
+		now := time.Date(2022, time.May, 1, 8, 45, 0, 0, time.UTC)
+		Expect(s.Prev(now)).Should(Equal(time.Date(2022, time.May, 1, 8, 40, 0, 0, time.UTC)))
+		Expect(s.Next(now)).Should(Equal(time.Date(2022, time.May, 1, 18, 0, 0, 0, time.UTC)))
+	})
+
+	It("should find activations more than a week away", func() {
+		// monthly maintenance window
+		s, err := ParseSchedule("0 2 1 * *")
+		Expect(err).ShouldNot(HaveOccurred())
+		now := time.Date(2022, time.May, 15, 12, 0, 0, 0, time.UTC)
+		Expect(s.Prev(now)).Should(Equal(time.Date(2022, time.May, 1, 2, 0, 0, 0, time.UTC)))
+		Expect(s.Next(now)).Should(Equal(time.Date(2022, time.June, 1, 2, 0, 0, 0, time.UTC)))
+
+		// yearly, on leap days only
+		s, err = ParseSchedule("0 0 29 2 *")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(s.Prev(now)).Should(Equal(time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)))
+		Expect(s.Next(now)).Should(Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should treat 7 as sunday", func() {
+		s, err := ParseSchedule("0 0 * * 7")
+		Expect(err).ShouldNot(HaveOccurred())
+		now := time.Date(2022, time.May, 9, 0, 0, 0, 0, time.UTC)
+		Expect(s.Prev(now)).Should(Equal(time.Date(2022, time.May, 8, 0, 0, 0, 0, time.UTC)))
+	})
+})
+
+func TestUtil(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Util Suite")
+}
@@ -20,6 +20,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -70,6 +71,7 @@ func init() {
 	customMetrics.Registry.MustRegister(controllers.DBaasInventoryRequestDurationSeconds)
 	customMetrics.Registry.MustRegister(controllers.DBaasConnectionRequestDurationSeconds)
 	customMetrics.Registry.MustRegister(controllers.DBaasInstanceRequestDurationSeconds)
+	customMetrics.Registry.MustRegister(controllers.DBaaSInstancePhaseDurationSeconds)
 	customMetrics.Registry.MustRegister(controllers.DBaasOperatorVersionInfo)
 
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
@@ -145,8 +147,19 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSConnection")
 		os.Exit(1)
 	}
+	credentialsSourceDir, found := os.LookupEnv(controllers.CredentialsSourceDirEnvVar)
+	if !found {
+		credentialsSourceDir = controllers.DefaultCredentialsSourceDir
+	}
+	var credentialsSourceAllowedHosts []string
+	if hosts := os.Getenv(controllers.CredentialsSourceAllowedHostsEnvVar); len(hosts) > 0 {
+		credentialsSourceAllowedHosts = strings.Split(hosts, ",")
+	}
+	v1alpha1.CredentialsSourceAllowedHosts = credentialsSourceAllowedHosts
 	inventoryCtrl, err := (&controllers.DBaaSInventoryReconciler{
-		DBaaSReconciler: DBaaSReconciler,
+		DBaaSReconciler:               DBaaSReconciler,
+		CredentialsSourceDir:          credentialsSourceDir,
+		CredentialsSourceAllowedHosts: credentialsSourceAllowedHosts,
 	}).SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSInventory")
@@ -154,13 +167,34 @@ func main() {
 	}
 	instanceCtrl, err := (&controllers.DBaaSInstanceReconciler{
 		DBaaSReconciler: DBaaSReconciler,
+		Recorder:        mgr.GetEventRecorderFor("dbaasinstance-controller"),
 	}).SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSInstance")
 		os.Exit(1)
 	}
-	if err = (&controllers.DBaaSDefaultPolicyReconciler{
+	backupCtrl, err := (&controllers.DBaaSBackupReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSBackup")
+		os.Exit(1)
+	}
+	restoreCtrl, err := (&controllers.DBaaSRestoreReconciler{
 		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSRestore")
+		os.Exit(1)
+	}
+	defaultPolicySpec, err := controllers.GetDefaultPolicySpec()
+	if err != nil {
+		setupLog.Error(err, "unable to read the default Policy")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBaaSDefaultPolicyReconciler{
+		DBaaSReconciler:   DBaaSReconciler,
+		DefaultPolicySpec: defaultPolicySpec,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSDefaultPolicy")
 		os.Exit(1)
@@ -170,6 +204,8 @@ func main() {
 		ConnectionCtrl:  connectionCtrl,
 		InventoryCtrl:   inventoryCtrl,
 		InstanceCtrl:    instanceCtrl,
+		BackupCtrl:      backupCtrl,
+		RestoreCtrl:     restoreCtrl,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSProvider")
 		os.Exit(1)
@@ -189,6 +225,18 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSPolicy")
 			os.Exit(1)
 		}
+		if err = (&v1alpha1.ClusterDBaaSPolicy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterDBaaSPolicy")
+			os.Exit(1)
+		}
+		if err = (&v1alpha1.DBaaSInstance{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSInstance")
+			os.Exit(1)
+		}
+		if err = (&v1alpha1.DBaaSInstanceApproval{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSInstanceApproval")
+			os.Exit(1)
+		}
 	}
 	if err = (&controllers.DBaaSPolicyReconciler{
 		DBaaSReconciler: DBaaSReconciler,
@@ -196,6 +244,18 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSPolicy")
 		os.Exit(1)
 	}
+	if err = (&controllers.DBaaSInventoryMigrationReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSInventoryMigration")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBaaSInventoryAccessReviewReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSInventoryAccessReview")
+		os.Exit(1)
+	}
 
 	var ocpVersion string
 	info, err := openshift.GetPlatformInfo(mgr.GetConfig())